@@ -0,0 +1,110 @@
+// Copyright (c) 2021 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// ConditionTypeBackupFailed is set when the most recent scheduled snapshot attempt failed.
+	ConditionTypeBackupFailed ConditionType = "BackupFailed"
+)
+
+// EtcdBackupPolicy defines the schedule and retention applied to an EtcdBackup.
+type EtcdBackupPolicy struct {
+	// BackupIntervalInSeconds is the interval, in seconds, between two successive snapshots.
+	BackupIntervalInSeconds int64 `json:"backupIntervalInSeconds"`
+	// MaxBackups is the maximum number of snapshots retained in the object store. Once exceeded,
+	// the oldest snapshots are deleted until at most MaxBackups remain. Defaults to 7.
+	// +optional
+	MaxBackups *int32 `json:"maxBackups,omitempty"`
+	// FullSnapshot indicates whether a full snapshot should be taken instead of a delta
+	// snapshot. Defaults to true.
+	// +optional
+	FullSnapshot *bool `json:"fullSnapshot,omitempty"`
+}
+
+// EtcdBackupSpec defines the desired state of EtcdBackup.
+type EtcdBackupSpec struct {
+	// EtcdRef refers to the Etcd resource, in the same namespace as this EtcdBackup, that this
+	// backup policy applies to.
+	EtcdRef CrossVersionObjectReference `json:"etcdRef"`
+	// BackupPolicy defines the schedule and retention applied to this EtcdBackup.
+	BackupPolicy EtcdBackupPolicy `json:"backupPolicy"`
+	// Store is the reference to an object store for backups. The EtcdBackup controller does not
+	// read it: snapshots are taken and pruned through the backup-restore sidecar, which always
+	// uses the Store configured on the referenced Etcd's Spec.Backup.
+	// +optional
+	Store *StoreSpec `json:"store,omitempty"`
+}
+
+// BackupFileInfo describes a single snapshot object recorded in the object store.
+type BackupFileInfo struct {
+	// Name is the name of the snapshot object in the object store.
+	Name string `json:"name"`
+	// Size is the size in bytes of the snapshot object.
+	Size int64 `json:"size"`
+	// CreationTime is the time the snapshot was taken.
+	CreationTime metav1.Time `json:"creationTime"`
+	// Etag is the entity tag reported for this snapshot object, used to detect whether the
+	// object has changed since it was last listed.
+	// +optional
+	Etag string `json:"etag,omitempty"`
+}
+
+// EtcdBackupStatus defines the observed state of EtcdBackup.
+type EtcdBackupStatus struct {
+	// ObservedGeneration is the most recent generation observed for this resource.
+	// +optional
+	ObservedGeneration *int64 `json:"observedGeneration,omitempty"`
+	// LastSuccessTime is the completion time of the most recent successful snapshot.
+	// +optional
+	LastSuccessTime *metav1.Time `json:"lastSuccessTime,omitempty"`
+	// Backups is the set of snapshot objects currently retained in the object store, ordered
+	// oldest first.
+	// +optional
+	Backups []BackupFileInfo `json:"backups,omitempty"`
+	// Conditions represents the latest available observations of the EtcdBackup's current state.
+	// +optional
+	Conditions []Condition `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:shortName="etcdbkp"
+// +kubebuilder:printcolumn:name="LastSuccess",type=date,JSONPath=`.status.lastSuccessTime`,description="time of the most recent successful snapshot"
+
+// EtcdBackup is the Schema for the etcdbackups API.
+type EtcdBackup struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   EtcdBackupSpec   `json:"spec"`
+	Status EtcdBackupStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// EtcdBackupList contains a list of EtcdBackup.
+type EtcdBackupList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []EtcdBackup `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&EtcdBackup{}, &EtcdBackupList{})
+}
@@ -0,0 +1,137 @@
+// Copyright (c) 2021 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ServiceStatus is a compact status summary of a Service belonging to an Etcd cluster.
+type ServiceStatus struct {
+	// Name is the name of the Service.
+	Name string `json:"name"`
+	// ClusterIP is the Service's assigned ClusterIP, or "None" for a headless Service.
+	// +optional
+	ClusterIP string `json:"clusterIP,omitempty"`
+}
+
+// ConfigMapStatus is a compact status summary of a ConfigMap belonging to an Etcd cluster.
+type ConfigMapStatus struct {
+	// Name is the name of the ConfigMap.
+	Name string `json:"name"`
+	// ResourceVersion is the ConfigMap's resource version as last observed.
+	// +optional
+	ResourceVersion string `json:"resourceVersion,omitempty"`
+}
+
+// StatefulSetStatus is a compact status summary of the StatefulSet belonging to an Etcd cluster.
+type StatefulSetStatus struct {
+	// Name is the name of the StatefulSet.
+	Name string `json:"name"`
+	// Ready indicates whether ReadyReplicas equals the desired Replicas.
+	Ready bool `json:"ready"`
+	// Replicas is the desired replica count.
+	Replicas int32 `json:"replicas"`
+	// ReadyReplicas is the number of ready replicas.
+	ReadyReplicas int32 `json:"readyReplicas"`
+	// UpdatedReplicas is the number of replicas running the updated revision.
+	UpdatedReplicas int32 `json:"updatedReplicas"`
+	// Conditions mirrors the StatefulSet's own status conditions.
+	// +optional
+	Conditions []Condition `json:"conditions,omitempty"`
+}
+
+// PodStatus is a compact status summary of a Pod belonging to an Etcd cluster.
+type PodStatus struct {
+	// Name is the name of the Pod.
+	Name string `json:"name"`
+	// Phase is the Pod's current phase.
+	Phase string `json:"phase"`
+	// Ready indicates whether the Pod's Ready condition is True.
+	Ready bool `json:"ready"`
+	// RestartCount is the highest restart count across the Pod's containers.
+	RestartCount int32 `json:"restartCount"`
+	// Conditions mirrors the Pod's own status conditions.
+	// +optional
+	Conditions []Condition `json:"conditions,omitempty"`
+}
+
+// PVCStatus is a compact status summary of a PersistentVolumeClaim belonging to an Etcd cluster.
+type PVCStatus struct {
+	// Name is the name of the PersistentVolumeClaim.
+	Name string `json:"name"`
+	// Phase is the PersistentVolumeClaim's current phase.
+	Phase string `json:"phase"`
+	// Conditions mirrors the PersistentVolumeClaim's own status conditions.
+	// +optional
+	Conditions []Condition `json:"conditions,omitempty"`
+}
+
+// EtcdResourceBundleStateSpec defines which Etcd this bundle aggregates child-object status for.
+type EtcdResourceBundleStateSpec struct {
+	// EtcdRef refers to the Etcd resource, in the same namespace as this
+	// EtcdResourceBundleState, whose child objects are aggregated here.
+	EtcdRef CrossVersionObjectReference `json:"etcdRef"`
+}
+
+// EtcdResourceBundleStateStatus aggregates a compact status summary of every Kubernetes object
+// druid manages on behalf of a single Etcd cluster, so operators can see all of them without
+// issuing multiple `kubectl get`s. Each slice is maintained independently, via server-side apply,
+// by the bundle controller that watches the corresponding object kind.
+type EtcdResourceBundleStateStatus struct {
+	// Services holds the status of the Etcd's Services.
+	// +optional
+	Services []ServiceStatus `json:"services,omitempty"`
+	// ConfigMaps holds the status of the Etcd's ConfigMaps.
+	// +optional
+	ConfigMaps []ConfigMapStatus `json:"configMaps,omitempty"`
+	// StatefulSets holds the status of the Etcd's StatefulSets.
+	// +optional
+	StatefulSets []StatefulSetStatus `json:"statefulSets,omitempty"`
+	// Pods holds the status of the Etcd's Pods.
+	// +optional
+	Pods []PodStatus `json:"pods,omitempty"`
+	// PersistentVolumeClaims holds the status of the Etcd's PersistentVolumeClaims.
+	// +optional
+	PersistentVolumeClaims []PVCStatus `json:"persistentVolumeClaims,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:shortName="ebs"
+
+// EtcdResourceBundleState is the Schema for the etcdresourcebundlestates API. It is a strictly
+// observational, read-only aggregation of the status of the Kubernetes objects druid manages for
+// a single Etcd cluster.
+type EtcdResourceBundleState struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   EtcdResourceBundleStateSpec   `json:"spec,omitempty"`
+	Status EtcdResourceBundleStateStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// EtcdResourceBundleStateList contains a list of EtcdResourceBundleState.
+type EtcdResourceBundleStateList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []EtcdResourceBundleState `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&EtcdResourceBundleState{}, &EtcdResourceBundleStateList{})
+}
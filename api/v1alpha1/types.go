@@ -0,0 +1,521 @@
+// Copyright (c) 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// ConditionType is the type of condition.
+type ConditionType string
+
+// ConditionStatus is the status of a condition.
+type ConditionStatus string
+
+const (
+	// ConditionTrue means a resource is in the condition.
+	ConditionTrue ConditionStatus = "True"
+	// ConditionFalse means a resource is not in the condition.
+	ConditionFalse ConditionStatus = "False"
+	// ConditionUnknown means Druid can't decide if a resource is in the condition or not.
+	ConditionUnknown ConditionStatus = "Unknown"
+)
+
+// Condition holds the information about the state of a resource.
+type Condition struct {
+	// Type of the Etcd condition.
+	Type ConditionType `json:"type"`
+	// Status of the condition, one of True, False, Unknown.
+	Status ConditionStatus `json:"status"`
+	// Last time the condition transitioned from one status to another.
+	LastTransitionTime metav1.Time `json:"lastTransitionTime"`
+	// Last time the condition was updated.
+	LastUpdateTime metav1.Time `json:"lastUpdateTime"`
+	// The reason for the condition's last transition.
+	Reason string `json:"reason"`
+	// A human readable message indicating details about the transition.
+	Message string `json:"message"`
+	// Owner identifies the actor responsible for maintaining this condition, e.g. "druid" or
+	// "etcd-backup-restore". Conditions owned by a different actor than the one performing a
+	// status update are left untouched by that update.
+	// +optional
+	Owner string `json:"owner,omitempty"`
+}
+
+const (
+	// ConditionTypeAllMembersReady is set when every etcd cluster member reports Ready.
+	ConditionTypeAllMembersReady ConditionType = "AllMembersReady"
+	// ConditionTypeQuorate is set when the etcd cluster has a quorum and a known leader.
+	ConditionTypeQuorate ConditionType = "Quorate"
+	// ConditionTypeBackupReady is set based on the health of the backup-restore sidecar's
+	// snapshotting leader election, maintained by the backup-restore actor.
+	ConditionTypeBackupReady ConditionType = "BackupReady"
+	// ConditionTypeMemberRemovalPending is set while druid is waiting for a scaled-down
+	// member to be removed from the etcd cluster before its pod may terminate.
+	ConditionTypeMemberRemovalPending ConditionType = "MemberRemovalPending"
+	// ConditionTypeAlarm reflects whether any etcd cluster member currently has an active
+	// NOSPACE or CORRUPT alarm raised, as last observed via the etcd client API.
+	ConditionTypeAlarm ConditionType = "Alarm"
+)
+
+const (
+	// ConditionOwnerDruid identifies conditions maintained by etcd-druid itself.
+	ConditionOwnerDruid = "druid"
+	// ConditionOwnerBackupRestore identifies conditions maintained by the backup-restore sidecar.
+	ConditionOwnerBackupRestore = "etcd-backup-restore"
+)
+
+// CrossVersionObjectReference contains enough information to let you identify the referred resource.
+type CrossVersionObjectReference struct {
+	// Kind of the referent.
+	Kind string `json:"kind,omitempty"`
+	// Name of the referent.
+	Name string `json:"name,omitempty"`
+	// API version of the referent.
+	APIVersion string `json:"apiVersion,omitempty"`
+}
+
+// CompactionMode is the mode of etcd auto-compaction.
+type CompactionMode string
+
+const (
+	// Periodic is a constant to set auto-compaction-mode to 'periodic'.
+	Periodic CompactionMode = "periodic"
+	// Revision is a constant to set auto-compaction-mode to 'revision'.
+	Revision CompactionMode = "revision"
+)
+
+// GarbageCollectionPolicy defines the type of policy for snapshot garbage collection.
+type GarbageCollectionPolicy string
+
+const (
+	// GarbageCollectionPolicyExponential defines the exponential policy for snapshot garbage collection.
+	GarbageCollectionPolicyExponential GarbageCollectionPolicy = "Exponential"
+	// GarbageCollectionPolicyLimitBased defines the limit based policy for snapshot garbage collection.
+	GarbageCollectionPolicyLimitBased GarbageCollectionPolicy = "LimitBased"
+)
+
+// CompressionPolicy defines the type of policy for snapshot compression.
+type CompressionPolicy string
+
+const (
+	// GzipCompression is constant for gzip compression policy.
+	GzipCompression CompressionPolicy = "gzip"
+)
+
+// StorageProvider defines the type of object store provider for storing backups.
+type StorageProvider string
+
+const (
+	// S3CompatibleStorageProvider is an S3-compatible object store reached via a custom endpoint,
+	// e.g. MinIO or Ceph RGW, configured via StoreSpec.S3Compatible.
+	S3CompatibleStorageProvider StorageProvider = "S3Compatible"
+	// LocalStorageProvider is a hostPath-backed store intended for local development and testing,
+	// configured via StoreSpec.Local.
+	LocalStorageProvider StorageProvider = "Local"
+)
+
+// CompressionSpec defines parameters for compressing the backups.
+type CompressionSpec struct {
+	// Enabled specifies whether compression of the backups is enabled.
+	Enabled bool `json:"enabled,omitempty"`
+	// Policy specifies the compression policy to be used. The default policy is gzip.
+	// +optional
+	Policy *CompressionPolicy `json:"policy,omitempty"`
+}
+
+// StoreSpec defines the specification of a snapshot store.
+type StoreSpec struct {
+	// Container is the name of the container the backup is stored at.
+	// +optional
+	Container *string `json:"container,omitempty"`
+	// Prefix is the prefix used for the store.
+	Prefix string `json:"prefix"`
+	// Provider is the name of the backup provider.
+	// +optional
+	Provider *StorageProvider `json:"provider,omitempty"`
+	// SecretRef is the reference to the secret which used to connect to the backup store.
+	// +optional
+	SecretRef *corev1.SecretReference `json:"secretRef,omitempty"`
+	// S3Compatible configures an S3-compatible store reached via a custom endpoint. Only
+	// meaningful when Provider is S3CompatibleStorageProvider.
+	// +optional
+	S3Compatible *S3CompatibleConfig `json:"s3Compatible,omitempty"`
+	// Local configures a hostPath-backed store. Only meaningful when Provider is
+	// LocalStorageProvider.
+	// +optional
+	Local *LocalConfig `json:"local,omitempty"`
+}
+
+// S3CompatibleConfig configures access to an S3-compatible object store, following the MinIO
+// client conventions for on-prem deployments (MinIO, Ceph RGW, etc.) that cannot be addressed
+// via the region-based `aws` provider.
+type S3CompatibleConfig struct {
+	// Endpoint is the S3-compatible endpoint URL, e.g. "https://minio.example.com:9000".
+	Endpoint string `json:"endpoint"`
+	// Region is the region to present to the endpoint. Many S3-compatible stores ignore this but
+	// still require some value to be set.
+	// +optional
+	Region string `json:"region,omitempty"`
+	// ForcePathStyle selects path-style addressing (https://host/bucket/key) instead of the
+	// virtual-hosted style (https://bucket.host/key) that most on-prem stores require.
+	// +optional
+	ForcePathStyle bool `json:"forcePathStyle,omitempty"`
+	// SSECSecretRef references a secret holding an SSE-C encryption key applied to objects
+	// written to the store. Mutually exclusive with SSEKMSKeyID.
+	// +optional
+	SSECSecretRef *corev1.SecretReference `json:"sseCSecretRef,omitempty"`
+	// SSEKMSKeyID is the ID of a server-side KMS key used to encrypt objects written to the
+	// store. Mutually exclusive with SSECSecretRef.
+	// +optional
+	SSEKMSKeyID *string `json:"sseKMSKeyID,omitempty"`
+}
+
+// LocalConfig configures a hostPath-backed snapshot store, intended for local development and
+// testing where no real object store is available.
+type LocalConfig struct {
+	// HostPath is the path on the node's filesystem under which snapshots are stored.
+	HostPath string `json:"hostPath"`
+}
+
+// TLSConfig hold the TLS config for either etcd server or client.
+type TLSConfig struct {
+	// TLSCASecretRef is the secret containing the CA certificate used to validate peer certificates.
+	TLSCASecretRef corev1.SecretReference `json:"tlsCASecretRef"`
+	// ServerTLSSecretRef is the secret containing the server TLS certificate and key.
+	ServerTLSSecretRef corev1.SecretReference `json:"serverTLSSecretRef"`
+	// ClientTLSSecretRef is the secret containing the client TLS certificate and key.
+	ClientTLSSecretRef corev1.SecretReference `json:"clientTLSSecretRef"`
+}
+
+// SharedConfig holds configuration shared by all etcd members.
+type SharedConfig struct {
+	// AutoCompactionMode is the auto-compaction-mode used by etcd.
+	// +optional
+	AutoCompactionMode *CompactionMode `json:"autoCompactionMode,omitempty"`
+	// AutoCompactionRetention is the auto-compaction-retention length for etcd.
+	// +optional
+	AutoCompactionRetention *string `json:"autoCompactionRetention,omitempty"`
+}
+
+// EtcdConfig defines the configuration for the etcd member pods.
+type EtcdConfig struct {
+	// Quota defines the etcd DB quota.
+	// +optional
+	Quota *resource.Quantity `json:"quota,omitempty"`
+	// DefragmentationSchedule defines the cron standard schedule for defragmentation of etcd.
+	// +optional
+	DefragmentationSchedule *string `json:"defragmentationSchedule,omitempty"`
+	// Image is the etcd container image.
+	// +optional
+	Image *string `json:"image,omitempty"`
+	// Metrics defines the level of metrics etcd should expose.
+	// +optional
+	Metrics *string `json:"metrics,omitempty"`
+	// Resources defines the compute resources for the etcd container.
+	// +optional
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+	// TLS contains the TLS config for the etcd server and client.
+	// +optional
+	TLS *TLSConfig `json:"tls,omitempty"`
+	// ServerPort is the port on which etcd server communication happens.
+	// +optional
+	ServerPort *int32 `json:"serverPort,omitempty"`
+	// ClientPort is the port on which etcd client communication happens.
+	// +optional
+	ClientPort *int32 `json:"clientPort,omitempty"`
+}
+
+// BackupSpec defines the parameters associated with the backup-restore sidecar.
+type BackupSpec struct {
+	// Image is the backup-restore container image.
+	// +optional
+	Image *string `json:"image,omitempty"`
+	// Store is the reference to the object store used to store backups.
+	// +optional
+	Store *StoreSpec `json:"store,omitempty"`
+	// Resources defines the compute resources for the backup-restore container.
+	// +optional
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+	// FullSnapshotSchedule defines the cron standard schedule for full snapshots.
+	// +optional
+	FullSnapshotSchedule *string `json:"fullSnapshotSchedule,omitempty"`
+	// GarbageCollectionPolicy defines the policy for garbage collecting old backups.
+	// +optional
+	GarbageCollectionPolicy *GarbageCollectionPolicy `json:"garbageCollectionPolicy,omitempty"`
+	// GarbageCollectionPeriod defines the period for garbage collecting old backups.
+	// +optional
+	GarbageCollectionPeriod *metav1.Duration `json:"garbageCollectionPeriod,omitempty"`
+	// DeltaSnapshotPeriod defines the period after which a delta snapshot is taken.
+	// +optional
+	DeltaSnapshotPeriod *metav1.Duration `json:"deltaSnapshotPeriod,omitempty"`
+	// DeltaSnapshotMemoryLimit defines the memory limit after which delta snapshots are taken.
+	// +optional
+	DeltaSnapshotMemoryLimit *resource.Quantity `json:"deltaSnapshotMemoryLimit,omitempty"`
+	// SnapshotCompression defines the specification for compression of Snapshots.
+	// +optional
+	SnapshotCompression *CompressionSpec `json:"compression,omitempty"`
+	// Port is the port on which the backup-restore server listens.
+	// +optional
+	Port *int32 `json:"port,omitempty"`
+	// TLS contains the TLS config for the backup-restore server, used by druid to poll its
+	// leader election and snapshot status.
+	// +optional
+	TLS *TLSConfig `json:"tls,omitempty"`
+}
+
+// RestoreSpec defines how to bootstrap a new etcd cluster from an existing backup instead of
+// starting from an empty data directory.
+type RestoreSpec struct {
+	// Source is the object store containing the full and delta snapshots to restore from.
+	Source StoreSpec `json:"source"`
+	// SnapshotName selects the full snapshot to restore from the store. "latest" restores from
+	// the most recent full snapshot and any delta snapshots layered on top of it. Defaults to
+	// "latest".
+	// +optional
+	SnapshotName *string `json:"snapshotName,omitempty"`
+	// Revision pins the restore to a specific etcd revision instead of the latest revision
+	// covered by the selected snapshot chain.
+	// +optional
+	Revision *int64 `json:"revision,omitempty"`
+}
+
+// EtcdSpec defines the desired state of Etcd.
+type EtcdSpec struct {
+	// Selector is a label query over pods that should match the replica count.
+	Selector *metav1.LabelSelector `json:"selector"`
+	// Labels are the labels to be applied on the resources managed by this Etcd.
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+	// Annotations are the annotations to be applied on the resources managed by this Etcd.
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+	// Etcd defines the parameters for the etcd container.
+	Etcd EtcdConfig `json:"etcd"`
+	// Backup defines the parameters for the backup-restore sidecar.
+	Backup BackupSpec `json:"backup"`
+	// Common contains shared configuration used by all etcd members.
+	// +optional
+	Common SharedConfig `json:"sharedConfig,omitempty"`
+	// Replicas is the number of desired etcd replicas.
+	Replicas int `json:"replicas"`
+	// StorageClass defines the name of the StorageClass to be used for the PersistentVolumeClaims.
+	// +optional
+	StorageClass *string `json:"storageClass,omitempty"`
+	// StorageCapacity defines the size of the PersistentVolumeClaims.
+	// +optional
+	StorageCapacity *resource.Quantity `json:"storageCapacity,omitempty"`
+	// VolumeClaimTemplate is the name of the VolumeClaimTemplate for the StatefulSet.
+	// +optional
+	VolumeClaimTemplate *string `json:"volumeClaimTemplate,omitempty"`
+	// PriorityClassName is the name of the priority class to be applied to the etcd pods.
+	// +optional
+	PriorityClassName *string `json:"priorityClassName,omitempty"`
+	// DisruptionBudget overrides the PodDisruptionBudget druid manages for this cluster's pods.
+	// +optional
+	DisruptionBudget *EtcdDisruptionBudget `json:"disruptionBudget,omitempty"`
+	// Restore bootstraps this etcd cluster from an existing backup instead of an empty data
+	// directory. It is only consulted the first time the StatefulSet is created; it has no
+	// effect on an Etcd resource whose StatefulSet already exists.
+	// +optional
+	Restore *RestoreSpec `json:"restore,omitempty"`
+}
+
+// EtcdDisruptionBudget allows overriding the PodDisruptionBudget druid manages for an Etcd
+// cluster's pods.
+type EtcdDisruptionBudget struct {
+	// Enabled controls whether druid manages a PodDisruptionBudget for this cluster at all.
+	// Defaults to true.
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+	// MinAvailable overrides the MinAvailable druid would otherwise derive from Spec.Replicas.
+	// +optional
+	MinAvailable *intstr.IntOrString `json:"minAvailable,omitempty"`
+	// MaxUnavailable overrides the MaxUnavailable druid would otherwise derive from Spec.Replicas.
+	// +optional
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
+}
+
+// RestorePhase is the progress of a Spec.Restore-driven bootstrap of a new etcd cluster from an
+// existing backup.
+type RestorePhase string
+
+const (
+	// RestorePhasePending is set once a restore has been requested but the StatefulSet pulling
+	// the backup has not yet been created.
+	RestorePhasePending RestorePhase = "Pending"
+	// RestorePhaseCopying is set once the StatefulSet has been created and its restore init
+	// container is expected to be copying the snapshot chain from Spec.Restore.Source.
+	RestorePhaseCopying RestorePhase = "Copying"
+	// RestorePhaseVerifying is set while druid waits for the restored StatefulSet to report
+	// ready, which requires the restore init container to have validated and applied the
+	// snapshot chain before the etcd container is allowed to start.
+	RestorePhaseVerifying RestorePhase = "Verifying"
+	// RestorePhaseReady is set once the restored StatefulSet has reported ready.
+	RestorePhaseReady RestorePhase = "Ready"
+)
+
+// EtcdRole is the Raft role a member currently holds.
+type EtcdRole string
+
+const (
+	// Leader is the Raft leader of the etcd cluster.
+	Leader EtcdRole = "Leader"
+	// Follower is a voting member that is not the Raft leader.
+	Follower EtcdRole = "Follower"
+	// Learner is a non-voting member that is catching up on the Raft log.
+	Learner EtcdRole = "Learner"
+)
+
+// EtcdMemberStatus holds the state of a single etcd cluster member as observed via the etcd client API.
+type EtcdMemberStatus struct {
+	// Name is the name of the etcd member, typically the pod name.
+	Name string `json:"name"`
+	// ID is the etcd-internal, hex-encoded member ID.
+	ID string `json:"id"`
+	// Role is the Raft role currently held by this member.
+	// +optional
+	Role *EtcdRole `json:"role,omitempty"`
+	// PeerURLs are the peer URLs this member advertises to the rest of the cluster.
+	// +optional
+	PeerURLs []string `json:"peerURLs,omitempty"`
+	// DBSize is the size in bytes of the member's backend database.
+	// +optional
+	DBSize *int64 `json:"dbSize,omitempty"`
+	// Revision is the current revision of the member's key-value store.
+	// +optional
+	Revision *int64 `json:"revision,omitempty"`
+	// RaftIndex is the current Raft applied index reported by the member.
+	// +optional
+	RaftIndex *int64 `json:"raftIndex,omitempty"`
+	// IsLearner indicates whether this member is a non-voting learner.
+	// +optional
+	IsLearner bool `json:"isLearner,omitempty"`
+	// LastTransitionTime is the last time this member's Ready status changed.
+	// +optional
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+	// LastHeartbeatTime is the last time a successful health check was observed for this member.
+	// +optional
+	LastHeartbeatTime metav1.Time `json:"lastHeartbeatTime,omitempty"`
+	// Ready indicates whether the member answered a status request on one of its client URLs.
+	// The readiness of the member's pod is not consulted.
+	// +optional
+	Ready *bool `json:"ready,omitempty"`
+	// Reason is a brief machine readable explanation for the member's current Ready status.
+	// +optional
+	Reason string `json:"reason,omitempty"`
+}
+
+// EtcdStatus defines the observed state of Etcd.
+type EtcdStatus struct {
+	// ObservedGeneration is the most recent generation observed for this resource.
+	// +optional
+	ObservedGeneration *int64 `json:"observedGeneration,omitempty"`
+	// Etcd is a reference to the StatefulSet owned by this Etcd resource.
+	// +optional
+	Etcd *CrossVersionObjectReference `json:"etcd,omitempty"`
+	// Conditions represents the latest available observations of the Etcd's current state.
+	// +optional
+	Conditions []Condition `json:"conditions,omitempty"`
+	// ServiceName is the name of the client Service used to access the etcd cluster.
+	// +optional
+	ServiceName *string `json:"serviceName,omitempty"`
+	// LastError represents the last occurred error.
+	// +optional
+	LastError *string `json:"lastError,omitempty"`
+	// Replicas is the replica count of the etcd resource.
+	// +optional
+	Replicas int `json:"replicas,omitempty"`
+	// Ready indicates whether the etcd cluster is ready and serving, which requires both
+	// StatefulSet readiness and a quorate etcd cluster with a known leader.
+	// +optional
+	Ready *bool `json:"ready,omitempty"`
+	// UpdatedReplicas is the count of updated replicas.
+	UpdatedReplicas int32 `json:"updatedReplicas,omitempty"`
+	// ReadyReplicas is the count of ready replicas.
+	ReadyReplicas int32 `json:"readyReplicas,omitempty"`
+	// CurrentReplicas is the count of current replicas.
+	CurrentReplicas int32 `json:"currentReplicas,omitempty"`
+	// ClusterSize is the number of members the etcd client API reported as part of the cluster.
+	// +optional
+	ClusterSize *int `json:"clusterSize,omitempty"`
+	// Quorate indicates whether the etcd cluster currently has a quorum.
+	// +optional
+	Quorate *bool `json:"quorate,omitempty"`
+	// LeaderID is the hex-encoded ID of the member the cluster currently considers the Raft
+	// leader, as last observed via the etcd client API.
+	// +optional
+	LeaderID *string `json:"leaderID,omitempty"`
+	// Members holds the per-member status as observed via the etcd client API, one entry per
+	// member in the order MemberList reports them. Entries are not sorted or matched by pod ordinal.
+	// +optional
+	Members []EtcdMemberStatus `json:"members,omitempty"`
+	// BackupLeader is the name of the etcd member whose backup-restore sidecar currently holds
+	// the snapshotting leadership, as reported by its /leader endpoint.
+	// +optional
+	BackupLeader *string `json:"backupLeader,omitempty"`
+	// LastSnapshot records the most recent on-demand snapshot requested via
+	// SnapshotOperationAnnotation, as reported back by the backup-restore sidecar.
+	// +optional
+	LastSnapshot *SnapshotReference `json:"lastSnapshot,omitempty"`
+	// RestorePhase is the progress of a Spec.Restore-driven bootstrap from an existing backup.
+	// It is only set for an Etcd resource whose Spec.Restore is non-nil.
+	// +optional
+	RestorePhase *RestorePhase `json:"restorePhase,omitempty"`
+}
+
+// SnapshotReference describes a single snapshot taken by the backup-restore sidecar.
+type SnapshotReference struct {
+	// Name is the name of the snapshot object in the object store.
+	Name string `json:"name"`
+	// Size is the size in bytes of the snapshot object.
+	Size int64 `json:"size"`
+	// CreationTime is the time the snapshot was taken.
+	CreationTime metav1.Time `json:"creationTime"`
+	// Store identifies the object store the snapshot was written to, e.g. the bucket or container
+	// name configured in Spec.Backup.Store.
+	// +optional
+	Store string `json:"store,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:shortName="etcd"
+// +kubebuilder:printcolumn:name="Ready",type=boolean,JSONPath=`.status.ready`,description="indicates whether the etcd members are ready"
+
+// Etcd is the Schema for the etcds API.
+type Etcd struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   EtcdSpec   `json:"spec"`
+	Status EtcdStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// EtcdList contains a list of Etcd.
+type EtcdList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Etcd `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&Etcd{}, &EtcdList{})
+}
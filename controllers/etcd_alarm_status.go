@@ -0,0 +1,177 @@
+// Copyright (c) 2021 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	druidv1alpha1 "github.com/gardener/etcd-druid/api/v1alpha1"
+	"github.com/gardener/etcd-druid/pkg/etcdclient"
+
+	kutil "github.com/gardener/gardener/pkg/utils/kubernetes"
+	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+)
+
+// DefaultDefragmentationCooldown is the minimum time that must elapse between two
+// NOSPACE-triggered defragmentation passes of the same Etcd cluster. Spec.Etcd.DefragmentationSchedule
+// is a cron expression meant for the backup-restore sidecar's own scheduled defragmentation; no
+// cron parser is vendored in this snapshot, so here its mere presence only opts an Etcd cluster
+// into alarm-triggered defragmentation, and this fixed cooldown is the actual rate limit, mirroring
+// EtcdReconciler's CrashLoopRemediationCooldown.
+const DefaultDefragmentationCooldown = 10 * time.Minute
+
+// reconcileAlarms probes the live etcd cluster for active alarms via the etcd client API and
+// records them in the Alarm condition. If a NOSPACE alarm is active, it compacts the cluster's
+// key-value store history up to its current revision, defragments every member sequentially to
+// reclaim the freed disk space, and disarms the alarm once done - subject to
+// DefaultDefragmentationCooldown so a cluster oscillating around its quota cannot be
+// defragmented continuously.
+func (ec *EtcdCustodian) reconcileAlarms(ctx context.Context, logger logr.Logger, etcd *druidv1alpha1.Etcd, sts *appsv1.StatefulSet) error {
+	clientPort := int32(2379)
+	if etcd.Spec.Etcd.ClientPort != nil {
+		clientPort = *etcd.Spec.Etcd.ClientPort
+	}
+	serviceName := fmt.Sprintf("%s-client", etcd.Name)
+	if etcd.Status.ServiceName != nil {
+		serviceName = *etcd.Status.ServiceName
+	}
+	replicas := int(*sts.Spec.Replicas)
+
+	cl, err := ec.etcdClientFactory.NewClient(ctx, etcd, serviceName, clientPort, replicas)
+	if err != nil {
+		return fmt.Errorf("unable to create etcd client: %w", err)
+	}
+	defer cl.Close()
+
+	alarms, err := cl.AlarmList(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := ec.updateAlarmStatus(ctx, etcd, alarms); err != nil {
+		return err
+	}
+
+	if etcd.Spec.Etcd.DefragmentationSchedule == nil || !hasAlarmType(alarms, etcdclient.AlarmNoSpace) {
+		return nil
+	}
+
+	if !ec.tryReserveDefragmentation(etcd.UID) {
+		logger.Info("NOSPACE alarm active but still within defragmentation cooldown, skipping", "etcd", kutil.Key(etcd.Namespace, etcd.Name).String())
+		return nil
+	}
+
+	logger.Info("NOSPACE alarm active, compacting and defragmenting before disarming", "etcd", kutil.Key(etcd.Namespace, etcd.Name).String())
+
+	members, err := cl.MemberList(ctx)
+	if err != nil {
+		return err
+	}
+
+	var compactRevision int64
+	for _, m := range members {
+		for _, endpoint := range m.ClientURLs {
+			status, statusErr := cl.Status(ctx, endpoint)
+			if statusErr != nil {
+				continue
+			}
+			if status.Revision > compactRevision {
+				compactRevision = status.Revision
+			}
+			break
+		}
+	}
+	if compactRevision > 0 {
+		if err := cl.Compact(ctx, compactRevision); err != nil {
+			return fmt.Errorf("unable to compact before clearing NOSPACE alarm: %w", err)
+		}
+	}
+
+	for _, m := range members {
+		for _, endpoint := range m.ClientURLs {
+			if err := cl.Defragment(ctx, endpoint); err != nil {
+				logger.Error(err, "unable to defragment member", "member", m.Name)
+			}
+			break
+		}
+	}
+
+	for _, alarm := range alarms {
+		if alarm.Type != etcdclient.AlarmNoSpace {
+			continue
+		}
+		if err := cl.AlarmDisarm(ctx, alarm); err != nil {
+			logger.Error(err, "unable to disarm NOSPACE alarm", "member", alarm.MemberID)
+		}
+	}
+
+	return nil
+}
+
+// updateAlarmStatus records whether any member currently has an active alarm in the Alarm
+// condition, so users can see quota exhaustion or corruption via `kubectl describe etcd` instead
+// of only via pod crashloops.
+func (ec *EtcdCustodian) updateAlarmStatus(ctx context.Context, etcd *druidv1alpha1.Etcd, alarms []etcdclient.Alarm) error {
+	status := druidv1alpha1.ConditionFalse
+	reason := "NoAlarms"
+	message := "No etcd alarms are currently active."
+	if len(alarms) > 0 {
+		status = druidv1alpha1.ConditionTrue
+		reason = string(alarms[0].Type)
+		message = fmt.Sprintf("%d etcd alarm(s) are currently active, most recently %s on member %s.", len(alarms), alarms[0].Type, alarms[0].MemberID)
+	}
+
+	return kutil.TryUpdateStatus(ctx, retry.DefaultBackoff, ec.Client, etcd, func() error {
+		etcd.Status.Conditions = mergeConditions(etcd.Status.Conditions, druidv1alpha1.ConditionOwnerDruid, druidv1alpha1.Condition{
+			Type:    druidv1alpha1.ConditionTypeAlarm,
+			Status:  status,
+			Reason:  reason,
+			Message: message,
+		})
+		return nil
+	})
+}
+
+// hasAlarmType reports whether any of the given alarms is of type t.
+func hasAlarmType(alarms []etcdclient.Alarm, t etcdclient.AlarmType) bool {
+	for _, alarm := range alarms {
+		if alarm.Type == t {
+			return true
+		}
+	}
+	return false
+}
+
+// tryReserveDefragmentation reports whether the Etcd identified by uid may be
+// NOSPACE-triggered-defragmented now, i.e. whether at least DefaultDefragmentationCooldown has
+// elapsed since the last such pass, and if so records the attempt.
+func (ec *EtcdCustodian) tryReserveDefragmentation(uid types.UID) bool {
+	ec.defragMu.Lock()
+	defer ec.defragMu.Unlock()
+
+	if ec.lastDefrag == nil {
+		ec.lastDefrag = map[types.UID]time.Time{}
+	}
+	if last, ok := ec.lastDefrag[uid]; ok && time.Since(last) < DefaultDefragmentationCooldown {
+		return false
+	}
+	ec.lastDefrag[uid] = time.Now()
+	return true
+}
@@ -0,0 +1,223 @@
+// Copyright (c) 2021 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	druidv1alpha1 "github.com/gardener/etcd-druid/api/v1alpha1"
+
+	kutil "github.com/gardener/gardener/pkg/utils/kubernetes"
+	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+)
+
+// DefaultFullSnapshotInterval is the fallback interval used when FullSnapshotSchedule cannot be
+// approximated (see approxFullSnapshotInterval) - the backup-restore sidecar's own default full
+// snapshot cadence.
+const DefaultFullSnapshotInterval = 24 * time.Hour
+
+// DefaultBackupStatusPollInterval is the default interval at which the backup-restore
+// sidecar's leader election endpoint is polled.
+const DefaultBackupStatusPollInterval = 30 * time.Second
+
+// DefaultBackupStatusTimeout is the default timeout applied to a single /leader poll.
+const DefaultBackupStatusTimeout = 5 * time.Second
+
+// defaultBackupRestorePort is used when Etcd.Spec.Backup.Port is not set.
+const defaultBackupRestorePort = 8080
+
+// leaderResponse is the shape returned by the backup-restore sidecar's /leader endpoint.
+type leaderResponse struct {
+	Name string `json:"name"`
+}
+
+// pollBackupLeader queries every etcd member's backup-restore sidecar /leader endpoint and
+// returns the name of the member that currently holds the snapshotting leadership, along with
+// the BackupReady condition reason that should be recorded.
+func (ec *EtcdCustodian) pollBackupLeader(ctx context.Context, logger logr.Logger, etcd *druidv1alpha1.Etcd, sts *appsv1.StatefulSet) (leader string, reason string, err error) {
+	port := int32(defaultBackupRestorePort)
+	if etcd.Spec.Backup.Port != nil {
+		port = *etcd.Spec.Backup.Port
+	}
+	scheme := "http"
+	httpClient := &http.Client{Timeout: ec.backupStatusTimeout}
+	if etcd.Spec.Backup.TLS != nil {
+		tlsConfig, tlsErr := ec.backupTLSConfig(ctx, etcd)
+		if tlsErr != nil {
+			return "", "", tlsErr
+		}
+		scheme = "https"
+		httpClient.Transport = &http.Transport{TLSClientConfig: tlsConfig}
+	}
+
+	peerServiceName := fmt.Sprintf("%s-peer", etcd.Name)
+	replicas := int(*sts.Spec.Replicas)
+	leaders := map[string]bool{}
+	reachable := 0
+
+	for ordinal := 0; ordinal < replicas; ordinal++ {
+		podName := fmt.Sprintf("%s-%d", etcd.Name, ordinal)
+		url := fmt.Sprintf("%s://%s.%s.%s:%d/leader", scheme, podName, peerServiceName, etcd.Namespace, port)
+
+		req, reqErr := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if reqErr != nil {
+			return "", "", reqErr
+		}
+		resp, httpErr := httpClient.Do(req)
+		if httpErr != nil {
+			logger.Info(fmt.Sprintf("could not reach backup-restore leader endpoint for %s: %v", podName, httpErr))
+			continue
+		}
+		reachable++
+
+		var body leaderResponse
+		decodeErr := json.NewDecoder(resp.Body).Decode(&body)
+		resp.Body.Close()
+		if decodeErr != nil || body.Name == "" {
+			continue
+		}
+		leaders[body.Name] = true
+	}
+
+	if reachable == 0 {
+		return "", "NoLeader", fmt.Errorf("no backup-restore sidecar was reachable")
+	}
+	if len(leaders) == 0 {
+		return "", "NoLeader", nil
+	}
+	if len(leaders) > 1 {
+		return "", "LeaderTransition", nil
+	}
+
+	for name := range leaders {
+		leader = name
+	}
+
+	if overdue, overdueErr := ec.isSnapshotOverdue(etcd); overdueErr == nil && overdue {
+		return leader, "SnapshotOverdue", nil
+	}
+	return leader, "Healthy", nil
+}
+
+// isSnapshotOverdue compares the configured full snapshot schedule's approximate interval
+// against how long it has been since this Etcd's last actually recorded snapshot. It is a
+// best-effort approximation since no cron parser is vendored in this snapshot to compute the
+// schedule's interval exactly.
+//
+// The baseline is Status.LastSnapshot's own CreationTime, not an in-memory cache: a cache that
+// this same check refreshes on every "not overdue" outcome resets far more often than the
+// configured interval (every statusSyncPeriod, not every real snapshot), so it would only ever
+// report overdue if the controller itself stopped reconciling - it would track controller
+// liveness, not whether a snapshot actually landed. Before the first snapshot is ever recorded,
+// the Etcd's own creation time is used as the baseline.
+func (ec *EtcdCustodian) isSnapshotOverdue(etcd *druidv1alpha1.Etcd) (bool, error) {
+	if etcd.Spec.Backup.FullSnapshotSchedule == nil {
+		return false, nil
+	}
+	interval := approxFullSnapshotInterval(*etcd.Spec.Backup.FullSnapshotSchedule)
+
+	baseline := etcd.CreationTimestamp.Time
+	if etcd.Status.LastSnapshot != nil {
+		baseline = etcd.Status.LastSnapshot.CreationTime.Time
+	}
+	return time.Since(baseline) > interval, nil
+}
+
+// approxFullSnapshotInterval returns a best-effort approximation of the interval implied by a
+// standard 5-field cron schedule. No cron parser is vendored in this snapshot, so only the
+// common "every N minutes" (*/N * * * *) and "every N hours" (0 */N * * *) step forms are
+// recognized; anything else falls back to DefaultFullSnapshotInterval.
+func approxFullSnapshotInterval(schedule string) time.Duration {
+	fields := strings.Fields(schedule)
+	if len(fields) != 5 {
+		return DefaultFullSnapshotInterval
+	}
+	if n, ok := cronStepValue(fields[0]); ok {
+		return time.Duration(n) * time.Minute
+	}
+	if fields[0] == "0" {
+		if n, ok := cronStepValue(fields[1]); ok {
+			return time.Duration(n) * time.Hour
+		}
+	}
+	return DefaultFullSnapshotInterval
+}
+
+// cronStepValue parses a cron field of the form "*/N", returning N if field has that form.
+func cronStepValue(field string) (int, bool) {
+	if !strings.HasPrefix(field, "*/") {
+		return 0, false
+	}
+	n, err := strconv.Atoi(strings.TrimPrefix(field, "*/"))
+	if err != nil || n <= 0 {
+		return 0, false
+	}
+	return n, true
+}
+
+func (ec *EtcdCustodian) backupTLSConfig(ctx context.Context, etcd *druidv1alpha1.Etcd) (*tls.Config, error) {
+	tlsSpec := etcd.Spec.Backup.TLS
+
+	caSecret := &corev1.Secret{}
+	if err := ec.Get(ctx, types.NamespacedName{Name: tlsSpec.TLSCASecretRef.Name, Namespace: etcd.Namespace}, caSecret); err != nil {
+		return nil, fmt.Errorf("unable to fetch backup-restore CA secret: %w", err)
+	}
+
+	caPool := x509.NewCertPool()
+	if !caPool.AppendCertsFromPEM(caSecret.Data[corev1.ServiceAccountRootCAKey]) {
+		return nil, fmt.Errorf("unable to parse CA certificate from secret %s/%s", caSecret.Namespace, caSecret.Name)
+	}
+
+	return &tls.Config{RootCAs: caPool}, nil
+}
+
+// updateBackupLeaderStatus polls the backup-restore leader endpoint and records the result as
+// Status.BackupLeader and a BackupReady condition on the Etcd resource.
+func (ec *EtcdCustodian) updateBackupLeaderStatus(ctx context.Context, logger logr.Logger, etcd *druidv1alpha1.Etcd, sts *appsv1.StatefulSet) error {
+	leader, reason, pollErr := ec.pollBackupLeader(ctx, logger, etcd, sts)
+	status := druidv1alpha1.ConditionTrue
+	message := "The backup-restore sidecar leader election is healthy."
+	if pollErr != nil || reason != "Healthy" {
+		status = druidv1alpha1.ConditionFalse
+		message = fmt.Sprintf("Backup-restore leader election is not healthy: %s", reason)
+	}
+
+	return kutil.TryUpdateStatus(ctx, retry.DefaultBackoff, ec.Client, etcd, func() error {
+		if leader != "" {
+			etcd.Status.BackupLeader = &leader
+		} else {
+			etcd.Status.BackupLeader = nil
+		}
+		etcd.Status.Conditions = mergeConditions(etcd.Status.Conditions, druidv1alpha1.ConditionOwnerBackupRestore, druidv1alpha1.Condition{
+			Type:    druidv1alpha1.ConditionTypeBackupReady,
+			Status:  status,
+			Reason:  reason,
+			Message: message,
+		})
+		return nil
+	})
+}
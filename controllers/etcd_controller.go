@@ -15,18 +15,22 @@
 package controllers
 
 import (
-	"bytes"
 	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
 	"fmt"
+	"net/http"
 	"path/filepath"
 	"reflect"
 	"strings"
+	"sync"
 	"time"
 
 	druidv1alpha1 "github.com/gardener/etcd-druid/api/v1alpha1"
-	"github.com/gardener/etcd-druid/pkg/chartrenderer"
-	"github.com/gardener/etcd-druid/pkg/client/kubernetes"
 	"github.com/gardener/etcd-druid/pkg/common"
+	"github.com/gardener/etcd-druid/pkg/etcdclient"
+	"github.com/gardener/etcd-druid/pkg/factory"
 	druidpredicates "github.com/gardener/etcd-druid/pkg/predicate"
 	"github.com/gardener/etcd-druid/pkg/utils"
 
@@ -41,6 +45,7 @@ import (
 	v1 "k8s.io/api/core/v1"
 	eventsv1 "k8s.io/api/events/v1"
 	eventsv1beta1 "k8s.io/api/events/v1beta1"
+	policyv1 "k8s.io/api/policy/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
@@ -48,8 +53,8 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	errorsutil "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/apimachinery/pkg/util/sets"
-	"k8s.io/apimachinery/pkg/util/yaml"
 	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/retry"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -82,6 +87,26 @@ const (
 	EtcdReady = true
 	// DefaultAutoCompactionRetention defines the default auto-compaction-retention length for etcd.
 	DefaultAutoCompactionRetention = "30m"
+	// DefaultCrashLoopRemediationCooldown is the minimum time that must elapse between two
+	// remediating deletes of the same crashlooping pod.
+	DefaultCrashLoopRemediationCooldown = 5 * time.Minute
+	// DefaultMaxConcurrentCrashLoopDeletes bounds how many crashlooping pods may be remediated by
+	// a single Etcd cluster at once, so a bad rollout can't have all of its pods kicked together.
+	DefaultMaxConcurrentCrashLoopDeletes = 1
+	// SnapshotOperationAnnotation, when set to SnapshotOperationValue, makes reconcileEtcd request
+	// an on-demand full snapshot from the backup-restore sidecar before the annotation is removed.
+	// It is distinct from v1beta1constants.GardenerOperation (the generic reconcile-now signal
+	// druidpredicates.HasOperationAnnotation watches for) since it requests a specific action
+	// rather than a bare nudge to reconcile, and is recognized by the dedicated
+	// hasSnapshotOperationAnnotation predicate below instead.
+	SnapshotOperationAnnotation = "druid.gardener.cloud/operation"
+	// SnapshotOperationValue is the only value of SnapshotOperationAnnotation recognized today.
+	SnapshotOperationValue = "snapshot"
+	// DefaultBackupRequestTimeout bounds a single HTTP call to the backup-restore sidecar made
+	// while handling an on-demand snapshot request.
+	DefaultBackupRequestTimeout = 30 * time.Second
+	// defaultBackupRestorePort is used when Etcd.Spec.Backup.Port is not set.
+	defaultBackupRestorePort = 8080
 )
 
 var (
@@ -92,11 +117,29 @@ var (
 // EtcdReconciler reconciles a Etcd object
 type EtcdReconciler struct {
 	client.Client
-	Scheme       *runtime.Scheme
-	chartApplier kubernetes.ChartApplier
-	Config       *rest.Config
-	ImageVector  imagevector.ImageVector
-	logger       logr.Logger
+	Scheme            *runtime.Scheme
+	Config            *rest.Config
+	ImageVector       imagevector.ImageVector
+	EtcdClientFactory *etcdclient.Factory
+	Recorder          record.EventRecorder
+	logger            logr.Logger
+
+	// CrashLoopRemediationCooldown is the minimum time that must elapse between two remediating
+	// deletes of the same crashlooping pod.
+	CrashLoopRemediationCooldown time.Duration
+	// MaxConcurrentCrashLoopDeletes bounds how many crashlooping pods of a single Etcd cluster may
+	// be remediated at once.
+	MaxConcurrentCrashLoopDeletes int
+
+	crashLoopMu    sync.Mutex
+	crashLoopState map[types.UID]*crashLoopRemediationState
+}
+
+// crashLoopRemediationState tracks the remediation history of a single crashlooping pod,
+// identified by its UID.
+type crashLoopRemediationState struct {
+	lastDelete  time.Time
+	deleteCount int
 }
 
 // NewReconcilerWithImageVector creates a new EtcdReconciler object with an image vector
@@ -110,12 +153,17 @@ func NewReconcilerWithImageVector(mgr manager.Manager) (*EtcdReconciler, error)
 
 // NewEtcdReconciler creates a new EtcdReconciler object
 func NewEtcdReconciler(mgr manager.Manager) (*EtcdReconciler, error) {
-	return (&EtcdReconciler{
-		Client: mgr.GetClient(),
-		Config: mgr.GetConfig(),
-		Scheme: mgr.GetScheme(),
-		logger: log.Log.WithName("etcd-controller"),
-	}).InitializeControllerWithChartApplier()
+	return &EtcdReconciler{
+		Client:                        mgr.GetClient(),
+		Config:                        mgr.GetConfig(),
+		Scheme:                        mgr.GetScheme(),
+		EtcdClientFactory:             etcdclient.NewFactory(mgr.GetClient(), DefaultEtcdConnectionTimeout),
+		Recorder:                      mgr.GetEventRecorderFor("etcd-controller"),
+		logger:                        log.Log.WithName("etcd-controller"),
+		CrashLoopRemediationCooldown:  DefaultCrashLoopRemediationCooldown,
+		MaxConcurrentCrashLoopDeletes: DefaultMaxConcurrentCrashLoopDeletes,
+		crashLoopState:                map[types.UID]*crashLoopRemediationState{},
+	}, nil
 }
 
 // NewEtcdReconcilerWithImageVector creates a new EtcdReconciler object
@@ -127,45 +175,10 @@ func NewEtcdReconcilerWithImageVector(mgr manager.Manager) (*EtcdReconciler, err
 	return ec.InitializeControllerWithImageVector()
 }
 
-func getChartPath() string {
-	return filepath.Join("charts", "etcd")
-}
-
-func getChartPathForStatefulSet() string {
-	return filepath.Join("etcd", "templates", "etcd-statefulset.yaml")
-}
-
-func getChartPathForConfigMap() string {
-	return filepath.Join("etcd", "templates", "etcd-configmap.yaml")
-}
-
-func getChartPathForService() string {
-	return filepath.Join("etcd", "templates", "etcd-service.yaml")
-}
-
 func getImageYAMLPath() string {
 	return filepath.Join(common.ChartPath, DefaultImageVector)
 }
 
-// InitializeControllerWithChartApplier will use EtcdReconciler client to initialize a Kubernetes client as well as
-// a Chart renderer.
-func (r *EtcdReconciler) InitializeControllerWithChartApplier() (*EtcdReconciler, error) {
-	if r.chartApplier != nil {
-		return r, nil
-	}
-
-	renderer, err := chartrenderer.NewForConfig(r.Config)
-	if err != nil {
-		return nil, err
-	}
-	applier, err := kubernetes.NewApplierForConfig(r.Config)
-	if err != nil {
-		return nil, err
-	}
-	r.chartApplier = kubernetes.NewChartApplier(renderer, applier)
-	return r, nil
-}
-
 // InitializeControllerWithImageVector will use EtcdReconciler client to initialize image vector for etcd
 // and backup restore images.
 func (r *EtcdReconciler) InitializeControllerWithImageVector() (*EtcdReconciler, error) {
@@ -235,6 +248,18 @@ func (r *EtcdReconciler) reconcile(ctx context.Context, etcd *druidv1alpha1.Etcd
 			Requeue: true,
 		}, err
 	}
+	if err := validateBackupStore(etcd); err != nil {
+		if err := r.updateEtcdErrorStatus(ctx, etcd, nil, err); err != nil {
+			logger.Error(err, "Error during reconciling ETCD")
+			return ctrl.Result{
+				Requeue: true,
+			}, err
+		}
+		return ctrl.Result{
+			Requeue: true,
+		}, err
+	}
+
 	svc, ss, err := r.reconcileEtcd(ctx, logger, etcd)
 	if err != nil {
 		if err := r.updateEtcdErrorStatus(ctx, etcd, ss, err); err != nil {
@@ -248,7 +273,13 @@ func (r *EtcdReconciler) reconcile(ctx context.Context, etcd *druidv1alpha1.Etcd
 		}, err
 	}
 
-	if err := r.updateEtcdStatus(ctx, etcd, svc, ss); err != nil {
+	members, leaderID, allMembersReady, err := r.probeMembers(ctx, logger, etcd, ss)
+	if err != nil {
+		logger.Info("Unable to probe etcd cluster members, requeuing", "error", err.Error())
+		return ctrl.Result{RequeueAfter: DefaultInterval}, nil
+	}
+
+	if err := r.updateEtcdStatus(ctx, etcd, svc, ss, members, leaderID, allMembersReady); err != nil {
 		return ctrl.Result{
 			Requeue: true,
 		}, err
@@ -274,6 +305,17 @@ func (r *EtcdReconciler) delete(ctx context.Context, etcd *druidv1alpha1.Etcd) (
 		}, err
 	}
 
+	if err := r.removeDependantPodDisruptionBudget(ctx, logger, etcd); err != nil {
+		if err := r.updateEtcdErrorStatus(ctx, etcd, nil, err); err != nil {
+			return ctrl.Result{
+				Requeue: true,
+			}, err
+		}
+		return ctrl.Result{
+			Requeue: true,
+		}, err
+	}
+
 	if err := r.removeFinalizersToDependantSecrets(ctx, logger, etcd); err != nil {
 		if err := r.updateEtcdErrorStatus(ctx, etcd, nil, err); err != nil {
 			return ctrl.Result{
@@ -304,7 +346,7 @@ func (r *EtcdReconciler) delete(ctx context.Context, etcd *druidv1alpha1.Etcd) (
 	return ctrl.Result{}, nil
 }
 
-func (r *EtcdReconciler) reconcileServices(ctx context.Context, logger logr.Logger, etcd *druidv1alpha1.Etcd, renderedChart *chartrenderer.RenderedChart) (*corev1.Service, error) {
+func (r *EtcdReconciler) reconcileServices(ctx context.Context, logger logr.Logger, etcd *druidv1alpha1.Etcd) (*corev1.Service, error) {
 	logger.Info("Reconciling etcd services")
 
 	selector, err := metav1.LabelSelectorAsSelector(etcd.Spec.Selector)
@@ -351,7 +393,7 @@ func (r *EtcdReconciler) reconcileServices(ctx context.Context, logger logr.Logg
 		}
 
 		// Service is claimed by for this etcd. Just sync the specs
-		if service, err = r.syncServiceSpec(ctx, logger, service, etcd, renderedChart); err != nil {
+		if service, err = r.syncServiceSpec(ctx, logger, service, etcd); err != nil {
 			return nil, err
 		}
 
@@ -360,7 +402,7 @@ func (r *EtcdReconciler) reconcileServices(ctx context.Context, logger logr.Logg
 
 	// Required Service doesn't exist. Create new
 
-	svc, err := r.getServiceFromEtcd(etcd, renderedChart)
+	svc, err := factory.BuildClientService(etcd)
 	if err != nil {
 		return nil, err
 	}
@@ -385,8 +427,8 @@ func (r *EtcdReconciler) reconcileServices(ctx context.Context, logger logr.Logg
 	return svc.DeepCopy(), err
 }
 
-func (r *EtcdReconciler) syncServiceSpec(ctx context.Context, logger logr.Logger, svc *corev1.Service, etcd *druidv1alpha1.Etcd, renderedChart *chartrenderer.RenderedChart) (*corev1.Service, error) {
-	decoded, err := r.getServiceFromEtcd(etcd, renderedChart)
+func (r *EtcdReconciler) syncServiceSpec(ctx context.Context, logger logr.Logger, svc *corev1.Service, etcd *druidv1alpha1.Etcd) (*corev1.Service, error) {
+	decoded, err := factory.BuildClientService(etcd)
 	if err != nil {
 		return nil, err
 	}
@@ -415,23 +457,65 @@ func (r *EtcdReconciler) syncServiceSpec(ctx context.Context, logger logr.Logger
 	return svcCopy, err
 }
 
-func (r *EtcdReconciler) getServiceFromEtcd(etcd *druidv1alpha1.Etcd, renderedChart *chartrenderer.RenderedChart) (*corev1.Service, error) {
-	var err error
-	decoded := &corev1.Service{}
-	servicePath := getChartPathForService()
-	if _, ok := renderedChart.Files()[servicePath]; !ok {
-		return nil, fmt.Errorf("missing service template file in the charts: %v", servicePath)
+// reconcilePeerService creates or updates the headless peer Service that the StatefulSet is
+// governed by and that gives every member a stable DNS name for --initial-cluster. Unlike
+// reconcileServices, it is addressed directly by its deterministic name rather than claimed via
+// the etcd selector, since that selector also matches the client Service and the two must not be
+// mistaken for duplicates of one another.
+func (r *EtcdReconciler) reconcilePeerService(ctx context.Context, logger logr.Logger, etcd *druidv1alpha1.Etcd) (*corev1.Service, error) {
+	logger.Info("Reconciling etcd peer service")
+
+	svc := &corev1.Service{}
+	err := r.Get(ctx, types.NamespacedName{Name: fmt.Sprintf("%s-peer", etcd.Name), Namespace: etcd.Namespace}, svc)
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			return nil, err
+		}
+
+		svc, err = factory.BuildPeerService(etcd)
+		if err != nil {
+			return nil, err
+		}
+		if err := r.Create(ctx, svc); err != nil {
+			return nil, err
+		}
+		if err := controllerutil.SetControllerReference(etcd, svc, r.Scheme); err != nil {
+			return nil, err
+		}
+		return svc.DeepCopy(), nil
+	}
+
+	return r.syncPeerServiceSpec(ctx, logger, svc, etcd)
+}
+
+func (r *EtcdReconciler) syncPeerServiceSpec(ctx context.Context, logger logr.Logger, svc *corev1.Service, etcd *druidv1alpha1.Etcd) (*corev1.Service, error) {
+	decoded, err := factory.BuildPeerService(etcd)
+	if err != nil {
+		return nil, err
 	}
 
-	decoder := yaml.NewYAMLOrJSONDecoder(bytes.NewReader([]byte(renderedChart.Files()[servicePath])), 1024)
+	if reflect.DeepEqual(svc.Spec, decoded.Spec) {
+		return svc, nil
+	}
+	svcCopy := svc.DeepCopy()
+	decoded.Spec.DeepCopyInto(&svcCopy.Spec)
+	// Copy ClusterIP as the field is immutable
+	svcCopy.Spec.ClusterIP = svc.Spec.ClusterIP
 
-	if err = decoder.Decode(&decoded); err != nil {
+	err = retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		return r.Patch(ctx, svcCopy, client.MergeFrom(svc))
+	})
+	if err == errorsutil.ErrPreconditionViolated {
+		logger.Info("Service precondition doesn't hold, skip updating it.", "service", kutil.Key(svc.Namespace, svc.Name).String())
+		err = nil
+	}
+	if err != nil {
 		return nil, err
 	}
-	return decoded, nil
+	return svcCopy, err
 }
 
-func (r *EtcdReconciler) reconcileConfigMaps(ctx context.Context, logger logr.Logger, etcd *druidv1alpha1.Etcd, renderedChart *chartrenderer.RenderedChart) (*corev1.ConfigMap, error) {
+func (r *EtcdReconciler) reconcileConfigMaps(ctx context.Context, logger logr.Logger, etcd *druidv1alpha1.Etcd) (*corev1.ConfigMap, error) {
 	logger.Info("Reconciling etcd configmap")
 
 	selector, err := metav1.LabelSelectorAsSelector(etcd.Spec.Selector)
@@ -477,16 +561,17 @@ func (r *EtcdReconciler) reconcileConfigMaps(ctx context.Context, logger logr.Lo
 		}
 
 		// ConfigMap is claimed by for this etcd. Just sync the data
-		if cm, err = r.syncConfigMapData(ctx, logger, cm, etcd, renderedChart); err != nil {
+		if cm, err = r.syncConfigMapData(ctx, logger, cm, etcd); err != nil {
 			return nil, err
 		}
 
 		return cm, err
 	}
 
-	// Required Configmap doesn't exist. Create new
-
-	cm, err := r.getConfigMapFromEtcd(etcd, renderedChart)
+	// Required Configmap doesn't exist. Create new. This is the only point at which the cluster
+	// is truly bootstrapping for the first time, so initial-cluster-state is "new" here and
+	// "existing" everywhere else (see syncConfigMapData).
+	cm, err := factory.BuildConfigMap(etcd, "new")
 	if err != nil {
 		return nil, err
 	}
@@ -511,8 +596,11 @@ func (r *EtcdReconciler) reconcileConfigMaps(ctx context.Context, logger logr.Lo
 	return cm.DeepCopy(), err
 }
 
-func (r *EtcdReconciler) syncConfigMapData(ctx context.Context, logger logr.Logger, cm *corev1.ConfigMap, etcd *druidv1alpha1.Etcd, renderedChart *chartrenderer.RenderedChart) (*corev1.ConfigMap, error) {
-	decoded, err := r.getConfigMapFromEtcd(etcd, renderedChart)
+func (r *EtcdReconciler) syncConfigMapData(ctx context.Context, logger logr.Logger, cm *corev1.ConfigMap, etcd *druidv1alpha1.Etcd) (*corev1.ConfigMap, error) {
+	// The bootstrap ConfigMap already exists, so this Etcd's cluster has already bootstrapped at
+	// least once: any member now reading initial-cluster-state for the first time is joining an
+	// already-running cluster (e.g. via a live MemberAdd on scale-up), never founding it.
+	decoded, err := factory.BuildConfigMap(etcd, "existing")
 	if err != nil {
 		return nil, err
 	}
@@ -539,25 +627,7 @@ func (r *EtcdReconciler) syncConfigMapData(ctx context.Context, logger logr.Logg
 	return cmCopy, err
 }
 
-func (r *EtcdReconciler) getConfigMapFromEtcd(etcd *druidv1alpha1.Etcd, renderedChart *chartrenderer.RenderedChart) (*corev1.ConfigMap, error) {
-	var err error
-	decoded := &corev1.ConfigMap{}
-	configMapPath := getChartPathForConfigMap()
-
-	if _, ok := renderedChart.Files()[configMapPath]; !ok {
-		return nil, fmt.Errorf("missing configmap template file in the charts: %v", configMapPath)
-	}
-
-	//logger.Infof("%v: %v", statefulsetPath, renderer.Files()[statefulsetPath])
-	decoder := yaml.NewYAMLOrJSONDecoder(bytes.NewReader([]byte(renderedChart.Files()[configMapPath])), 1024)
-
-	if err = decoder.Decode(&decoded); err != nil {
-		return nil, err
-	}
-	return decoded, nil
-}
-
-func (r *EtcdReconciler) reconcileStatefulSet(ctx context.Context, logger logr.Logger, etcd *druidv1alpha1.Etcd, values map[string]interface{}) (*appsv1.StatefulSet, error) {
+func (r *EtcdReconciler) reconcileStatefulSet(ctx context.Context, logger logr.Logger, etcd *druidv1alpha1.Etcd) (*appsv1.StatefulSet, error) {
 	logger.Info("Reconciling etcd statefulset")
 
 	// If any adoptions are attempted, we should first recheck for deletion with
@@ -615,8 +685,17 @@ func (r *EtcdReconciler) reconcileStatefulSet(ctx context.Context, logger logr.L
 			return nil, err
 		}
 
+		desiredReplicas := etcd.Spec.Replicas
+		if desiredReplicas < 1 {
+			desiredReplicas = 1
+		}
+		if err := r.reconcileMembership(ctx, logger, etcd, sts, desiredReplicas); err != nil {
+			logger.Error(err, "Error reconciling etcd membership ahead of scale-up")
+			return nil, err
+		}
+
 		// Statefulset is claimed by for this etcd. Just sync the specs
-		if sts, err = r.syncStatefulSetSpec(ctx, logger, sts, etcd, values); err != nil {
+		if sts, err = r.syncStatefulSetSpec(ctx, logger, sts, etcd); err != nil {
 			return nil, err
 		}
 
@@ -631,24 +710,29 @@ func (r *EtcdReconciler) reconcileStatefulSet(ctx context.Context, logger logr.L
 			return nil, err
 		}
 
-		for _, pod := range podList.Items {
-			if utils.IsPodInCrashloopBackoff(pod.Status) {
-				if err := r.Delete(ctx, &pod); err != nil {
-					logger.Error(err, fmt.Sprintf("error deleting etcd pod in crashloop: %s/%s", pod.Namespace, pod.Name))
-					return nil, err
-				}
-			}
+		if err := r.remediateCrashLoopingPods(ctx, logger, etcd, podList.Items); err != nil {
+			return nil, err
 		}
 
 		return r.waitUntilStatefulSetReady(ctx, logger, etcd, sts)
 	}
 
 	// Required statefulset doesn't exist. Create new
-	sts, err := r.getStatefulSetFromEtcd(etcd, values)
+	sts, err := factory.BuildStatefulSet(etcd, r.ImageVector)
 	if err != nil {
 		return nil, err
 	}
 
+	if err := controllerutil.SetControllerReference(etcd, sts, r.Scheme); err != nil {
+		return nil, err
+	}
+
+	if etcd.Spec.Restore != nil {
+		if err := r.updateEtcdRestoreStatus(ctx, etcd, druidv1alpha1.RestorePhasePending); err != nil {
+			return nil, err
+		}
+	}
+
 	err = r.Create(ctx, sts)
 
 	// Ignore the precondition violated error, this machine is already updated
@@ -661,7 +745,208 @@ func (r *EtcdReconciler) reconcileStatefulSet(ctx context.Context, logger logr.L
 		return nil, err
 	}
 
-	return r.waitUntilStatefulSetReady(ctx, logger, etcd, sts)
+	if etcd.Spec.Restore != nil {
+		if err := r.updateEtcdRestoreStatus(ctx, etcd, druidv1alpha1.RestorePhaseCopying); err != nil {
+			return nil, err
+		}
+		if err := r.updateEtcdRestoreStatus(ctx, etcd, druidv1alpha1.RestorePhaseVerifying); err != nil {
+			return nil, err
+		}
+	}
+
+	ss, err := r.waitUntilStatefulSetReady(ctx, logger, etcd, sts)
+	if err != nil {
+		return ss, err
+	}
+
+	if etcd.Spec.Restore != nil {
+		if err := r.updateEtcdRestoreStatus(ctx, etcd, druidv1alpha1.RestorePhaseReady); err != nil {
+			return ss, err
+		}
+	}
+
+	return ss, nil
+}
+
+// remediateCrashLoopingPods deletes pods in CrashLoopBackOff, rate-limited per pod by
+// CrashLoopRemediationCooldown and bounded cluster-wide by MaxConcurrentCrashLoopDeletes, so a
+// stampede of crashlooping pods cannot all be kicked at once and break quorum. Before deleting a
+// pod that is currently the Raft leader, it hands off leadership first.
+func (r *EtcdReconciler) remediateCrashLoopingPods(ctx context.Context, logger logr.Logger, etcd *druidv1alpha1.Etcd, pods []v1.Pod) error {
+	cooldown := r.CrashLoopRemediationCooldown
+	if cooldown <= 0 {
+		cooldown = DefaultCrashLoopRemediationCooldown
+	}
+	maxConcurrent := r.MaxConcurrentCrashLoopDeletes
+	if maxConcurrent <= 0 {
+		maxConcurrent = DefaultMaxConcurrentCrashLoopDeletes
+	}
+
+	var crashlooping []v1.Pod
+	for _, pod := range pods {
+		if utils.IsPodInCrashloopBackoff(pod.Status) {
+			crashlooping = append(crashlooping, pod)
+		}
+	}
+	if len(crashlooping) == 0 {
+		return nil
+	}
+
+	members, leaderID, err := r.currentLeader(ctx, logger, etcd)
+	if err != nil {
+		logger.Info("Unable to determine current etcd leader before crashloop remediation, proceeding without leader hand-off", "error", err.Error())
+	}
+
+	remediated := 0
+	for _, pod := range crashlooping {
+		if remediated >= maxConcurrent {
+			logger.Info("Reached MaxConcurrentCrashLoopDeletes, deferring remaining crashlooping pods to a later reconcile", "pod", pod.Name, "maxConcurrentCrashLoopDeletes", maxConcurrent)
+			break
+		}
+		if !r.tryReserveCrashLoopDelete(pod.UID, cooldown) {
+			logger.Info("Pod is crashlooping but still within its remediation cooldown, skipping", "pod", pod.Name, "cooldown", cooldown)
+			continue
+		}
+
+		if leaderID != "" {
+			if memberID, ok := matchPodToMember(pod, members); ok && memberID == leaderID {
+				if err := r.moveLeaderAwayFrom(ctx, etcd, members, memberID); err != nil {
+					logger.Error(err, "Unable to move etcd leader before remediating crashlooping leader pod", "pod", pod.Name)
+				}
+			}
+		}
+
+		if err := r.Delete(ctx, &pod); err != nil {
+			return fmt.Errorf("error deleting etcd pod in crashloop: %s/%s: %w", pod.Namespace, pod.Name, err)
+		}
+		r.Recorder.Eventf(etcd, v1.EventTypeWarning, "CrashLoopRemediation", "Deleted pod %s after it was found in CrashLoopBackOff (cooldown %s)", pod.Name, cooldown)
+		remediated++
+	}
+	return nil
+}
+
+// tryReserveCrashLoopDelete reports whether podUID may be remediated now, i.e. whether at least
+// cooldown has elapsed since its last remediating delete, and if so records the attempt.
+func (r *EtcdReconciler) tryReserveCrashLoopDelete(podUID types.UID, cooldown time.Duration) bool {
+	r.crashLoopMu.Lock()
+	defer r.crashLoopMu.Unlock()
+
+	if r.crashLoopState == nil {
+		r.crashLoopState = map[types.UID]*crashLoopRemediationState{}
+	}
+	state, ok := r.crashLoopState[podUID]
+	if ok && time.Since(state.lastDelete) < cooldown {
+		return false
+	}
+	if !ok {
+		state = &crashLoopRemediationState{}
+		r.crashLoopState[podUID] = state
+	}
+	state.lastDelete = time.Now()
+	state.deleteCount++
+	return true
+}
+
+// currentLeader probes the live etcd cluster for its current members and Raft leader, the same
+// way probeMembers does, so crashloop remediation can avoid deleting the leader outright.
+func (r *EtcdReconciler) currentLeader(ctx context.Context, logger logr.Logger, etcd *druidv1alpha1.Etcd) ([]etcdclient.Member, string, error) {
+	serviceName := fmt.Sprintf("%s-client", etcd.Name)
+	if etcd.Status.ServiceName != nil {
+		serviceName = *etcd.Status.ServiceName
+	}
+	clientPort := int32(2379)
+	if etcd.Spec.Etcd.ClientPort != nil {
+		clientPort = *etcd.Spec.Etcd.ClientPort
+	}
+	replicas := etcd.Spec.Replicas
+	if replicas == 0 {
+		replicas = 1
+	}
+
+	cli, err := r.EtcdClientFactory.NewClient(ctx, etcd, serviceName, clientPort, replicas)
+	if err != nil {
+		return nil, "", err
+	}
+	defer func() {
+		if err := cli.Close(); err != nil {
+			logger.Error(err, "Error closing etcd client")
+		}
+	}()
+
+	members, err := cli.MemberList(ctx)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var leaderID string
+	for _, m := range members {
+		for _, endpoint := range m.ClientURLs {
+			status, statusErr := cli.Status(ctx, endpoint)
+			if statusErr != nil {
+				continue
+			}
+			if status.Leader != "" {
+				leaderID = status.Leader
+			}
+			break
+		}
+		if leaderID != "" {
+			break
+		}
+	}
+	return members, leaderID, nil
+}
+
+// matchPodToMember returns the hex-encoded member ID corresponding to pod, identified by member
+// name matching the pod's name or, for the common single-replica case where the bootstrap
+// ConfigMap names the sole member after the Etcd rather than the pod, the cluster's only member.
+func matchPodToMember(pod v1.Pod, members []etcdclient.Member) (string, bool) {
+	for _, m := range members {
+		if m.Name == pod.Name {
+			return m.ID, true
+		}
+	}
+	if len(members) == 1 {
+		return members[0].ID, true
+	}
+	return "", false
+}
+
+// moveLeaderAwayFrom transfers Raft leadership from leaderID to another member in members, if
+// any. The etcd MoveLeader RPC fails unless it is issued against the leader itself, so this dials
+// the leader's own client endpoint directly rather than going through the load-balanced
+// multi-endpoint client used elsewhere, which could route the call to any member.
+func (r *EtcdReconciler) moveLeaderAwayFrom(ctx context.Context, etcd *druidv1alpha1.Etcd, members []etcdclient.Member, leaderID string) error {
+	var transferee, leaderEndpoint string
+	for _, m := range members {
+		if m.ID == leaderID {
+			if len(m.ClientURLs) > 0 {
+				leaderEndpoint = m.ClientURLs[0]
+			}
+			continue
+		}
+		if transferee == "" && !m.IsLearner {
+			transferee = m.ID
+		}
+	}
+	if transferee == "" {
+		return fmt.Errorf("no other voting member available to transfer leadership to")
+	}
+	if leaderEndpoint == "" {
+		return fmt.Errorf("unable to determine client endpoint for leader member %q", leaderID)
+	}
+
+	cli, err := r.EtcdClientFactory.NewSingleEndpointClient(ctx, etcd, leaderEndpoint)
+	if err != nil {
+		return err
+	}
+	defer cli.Close()
+
+	if err := cli.MoveLeader(ctx, transferee); err != nil {
+		return err
+	}
+	r.Recorder.Eventf(etcd, v1.EventTypeNormal, "CrashLoopRemediation", "Moved etcd leader from member %s to %s ahead of pod remediation", leaderID, transferee)
+	return nil
 }
 
 func getContainerMapFromPodTemplateSpec(spec v1.PodSpec) map[string]v1.Container {
@@ -672,11 +957,79 @@ func getContainerMapFromPodTemplateSpec(spec v1.PodSpec) map[string]v1.Container
 	return containers
 }
 
-func (r *EtcdReconciler) syncStatefulSetSpec(ctx context.Context, logger logr.Logger, ss *appsv1.StatefulSet, etcd *druidv1alpha1.Etcd, values map[string]interface{}) (*appsv1.StatefulSet, error) {
-	decoded, err := r.getStatefulSetFromEtcd(etcd, values)
+// reconcileMembership registers a live MemberAdd for every new member implied by scaling sts up
+// to desiredReplicas, so each new member's peer URL is already known to the Raft cluster before
+// its pod starts trying to join. It is a no-op on scale-down or when the cluster has no ready
+// member yet to accept the request (BuildStatefulSet's static initial-cluster already covers the
+// initial bootstrap case). The symmetric scale-down removal is handled by
+// EtcdCustodian.reconcileScaleDown instead of here, since a member must keep serving until its
+// pod has actually been deleted, not merely until the StatefulSet's desired replica count drops.
+func (r *EtcdReconciler) reconcileMembership(ctx context.Context, logger logr.Logger, etcd *druidv1alpha1.Etcd, sts *appsv1.StatefulSet, desiredReplicas int) error {
+	currentReplicas := 1
+	if sts.Spec.Replicas != nil && int(*sts.Spec.Replicas) > currentReplicas {
+		currentReplicas = int(*sts.Spec.Replicas)
+	}
+	if desiredReplicas <= currentReplicas || sts.Status.ReadyReplicas == 0 {
+		return nil
+	}
+
+	serviceName := fmt.Sprintf("%s-client", etcd.Name)
+	if etcd.Status.ServiceName != nil {
+		serviceName = *etcd.Status.ServiceName
+	}
+	clientPort := int32(2379)
+	if etcd.Spec.Etcd.ClientPort != nil {
+		clientPort = *etcd.Spec.Etcd.ClientPort
+	}
+	cli, err := r.EtcdClientFactory.NewClient(ctx, etcd, serviceName, clientPort, currentReplicas)
+	if err != nil {
+		return err
+	}
+	defer cli.Close()
+
+	existingMembers, err := cli.MemberList(ctx)
+	if err != nil {
+		return err
+	}
+	knownPeerURLs := map[string]bool{}
+	for _, m := range existingMembers {
+		for _, peerURL := range m.PeerURLs {
+			knownPeerURLs[peerURL] = true
+		}
+	}
+
+	serverPort := int32(2380)
+	if etcd.Spec.Etcd.ServerPort != nil {
+		serverPort = *etcd.Spec.Etcd.ServerPort
+	}
+	scheme := "http"
+	if etcd.Spec.Etcd.TLS != nil {
+		scheme = "https"
+	}
+
+	for ordinal := currentReplicas; ordinal < desiredReplicas; ordinal++ {
+		memberName := fmt.Sprintf("%s-%d", etcd.Name, ordinal)
+		peerURL := fmt.Sprintf("%s://%s.%s-peer.%s:%d", scheme, memberName, etcd.Name, etcd.Namespace, serverPort)
+		if knownPeerURLs[peerURL] {
+			// Already added by a previous reconcile whose StatefulSet patch didn't land yet.
+			continue
+		}
+		if err := cli.MemberAdd(ctx, peerURL); err != nil {
+			return fmt.Errorf("unable to add etcd member %s ahead of scale-up: %w", memberName, err)
+		}
+		logger.Info("Added new etcd member to live cluster ahead of scale-up", "member", memberName, "peerURL", peerURL)
+	}
+	return nil
+}
+
+func (r *EtcdReconciler) syncStatefulSetSpec(ctx context.Context, logger logr.Logger, ss *appsv1.StatefulSet, etcd *druidv1alpha1.Etcd) (*appsv1.StatefulSet, error) {
+	decoded, err := factory.BuildStatefulSet(etcd, r.ImageVector)
 	if err != nil {
 		return nil, err
 	}
+	// Spec.Restore only shapes a newly created StatefulSet: keep the live init containers so a
+	// restore init container is neither added to nor removed from an existing one.
+	decoded.Spec.Template.Spec.InitContainers = ss.Spec.Template.Spec.InitContainers
 
 	if reflect.DeepEqual(ss.Spec, decoded.Spec) {
 		return ss, nil
@@ -738,62 +1091,188 @@ func (r *EtcdReconciler) recreateStatefulset(ctx context.Context, ss *appsv1.Sta
 	return err
 }
 
-func (r *EtcdReconciler) getStatefulSetFromEtcd(etcd *druidv1alpha1.Etcd, values map[string]interface{}) (*appsv1.StatefulSet, error) {
-	var err error
-	decoded := &appsv1.StatefulSet{}
-	statefulSetPath := getChartPathForStatefulSet()
-	chartPath := getChartPath()
-	renderedChart, err := r.chartApplier.Render(chartPath, etcd.Name, etcd.Namespace, values)
+// requestOnDemandSnapshot asks the backup-restore sidecar for an immediate full snapshot when
+// SnapshotOperationAnnotation is set to SnapshotOperationValue, and records the result in
+// etcd.Status.LastSnapshot. It is a no-op otherwise. The annotation itself is cleared by
+// removeOperationAnnotation once the reconcile that observed it completes, the same way every
+// other operation-annotation-triggered action is cleared.
+func (r *EtcdReconciler) requestOnDemandSnapshot(ctx context.Context, logger logr.Logger, etcd *druidv1alpha1.Etcd, svc *corev1.Service) error {
+	if etcd.Annotations[SnapshotOperationAnnotation] != SnapshotOperationValue {
+		return nil
+	}
+	logger.Info("On-demand snapshot requested via annotation", "annotation", SnapshotOperationAnnotation, "value", SnapshotOperationValue)
+
+	if err := r.doBackupRestoreRequest(ctx, etcd, svc, http.MethodPost, "/snapshot/full", nil); err != nil {
+		return fmt.Errorf("unable to request on-demand full snapshot: %w", err)
+	}
+
+	var listed []druidv1alpha1.BackupFileInfo
+	if err := r.doBackupRestoreRequest(ctx, etcd, svc, http.MethodGet, "/snapshot/list", &listed); err != nil {
+		return fmt.Errorf("unable to list snapshots after on-demand snapshot request: %w", err)
+	}
+	if len(listed) == 0 {
+		return fmt.Errorf("backup-restore reported no snapshots after on-demand snapshot request")
+	}
+	newest := listed[0]
+	for _, candidate := range listed[1:] {
+		if candidate.CreationTime.Time.After(newest.CreationTime.Time) {
+			newest = candidate
+		}
+	}
+
+	var store string
+	if etcd.Spec.Backup.Store != nil && etcd.Spec.Backup.Store.Container != nil {
+		store = *etcd.Spec.Backup.Store.Container
+	}
+
+	return kutil.TryUpdateStatus(ctx, retry.DefaultBackoff, r.Client, etcd, func() error {
+		etcd.Status.LastSnapshot = &druidv1alpha1.SnapshotReference{
+			Name:         newest.Name,
+			Size:         newest.Size,
+			CreationTime: newest.CreationTime,
+			Store:        store,
+		}
+		return nil
+	})
+}
+
+// doBackupRestoreRequest issues an HTTP request against the backup-restore sidecar fronted by
+// svc, the client Service resolved by reconcileServices, optionally decoding a JSON response body
+// into out. It mirrors etcdbackup.Reconciler.doRequest, duplicated here since pkg/controllers/etcdbackup
+// must not import the top-level controllers package and vice versa.
+func (r *EtcdReconciler) doBackupRestoreRequest(ctx context.Context, etcd *druidv1alpha1.Etcd, svc *corev1.Service, method, path string, out interface{}) error {
+	port := int32(defaultBackupRestorePort)
+	if etcd.Spec.Backup.Port != nil {
+		port = *etcd.Spec.Backup.Port
+	}
+	scheme := "http"
+	httpClient := &http.Client{Timeout: DefaultBackupRequestTimeout}
+	if etcd.Spec.Backup.TLS != nil {
+		tlsConfig, err := r.backupRestoreTLSConfig(ctx, etcd)
+		if err != nil {
+			return err
+		}
+		scheme = "https"
+		httpClient.Transport = &http.Transport{TLSClientConfig: tlsConfig}
+	}
+
+	url := fmt.Sprintf("%s://%s.%s:%d%s", scheme, svc.Name, etcd.Namespace, port, path)
+
+	ctx, cancel := context.WithTimeout(ctx, DefaultBackupRequestTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, method, url, nil)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	if _, ok := renderedChart.Files()[statefulSetPath]; !ok {
-		return nil, fmt.Errorf("missing configmap template file in the charts: %v", statefulSetPath)
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("backup-restore request %s %s failed: %w", method, path, err)
 	}
+	defer resp.Body.Close()
 
-	decoder := yaml.NewYAMLOrJSONDecoder(bytes.NewReader([]byte(renderedChart.Files()[statefulSetPath])), 1024)
-	if err = decoder.Decode(&decoded); err != nil {
-		return nil, err
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("backup-restore request %s %s returned status %d", method, path, resp.StatusCode)
+	}
+	if out == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+func (r *EtcdReconciler) backupRestoreTLSConfig(ctx context.Context, etcd *druidv1alpha1.Etcd) (*tls.Config, error) {
+	tlsSpec := etcd.Spec.Backup.TLS
+
+	caSecret := &corev1.Secret{}
+	if err := r.Get(ctx, types.NamespacedName{Name: tlsSpec.TLSCASecretRef.Name, Namespace: etcd.Namespace}, caSecret); err != nil {
+		return nil, fmt.Errorf("unable to fetch backup-restore CA secret: %w", err)
+	}
+
+	caPool := x509.NewCertPool()
+	if !caPool.AppendCertsFromPEM(caSecret.Data[corev1.ServiceAccountRootCAKey]) {
+		return nil, fmt.Errorf("unable to parse CA certificate from secret %s/%s", caSecret.Namespace, caSecret.Name)
 	}
-	return decoded, nil
+
+	return &tls.Config{RootCAs: caPool}, nil
 }
 
 func (r *EtcdReconciler) reconcileEtcd(ctx context.Context, logger logr.Logger, etcd *druidv1alpha1.Etcd) (*corev1.Service, *appsv1.StatefulSet, error) {
+	// getMapFromEtcd is still consulted here so that a missing required image is caught before
+	// any object is created or patched, not half-way through.
+	if _, err := r.getMapFromEtcd(etcd); err != nil {
+		return nil, nil, err
+	}
 
-	values, err := r.getMapFromEtcd(etcd)
+	svc, err := r.reconcileServices(ctx, logger, etcd)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	chartPath := getChartPath()
-	renderedChart, err := r.chartApplier.Render(chartPath, etcd.Name, etcd.Namespace, values)
-	if err != nil {
+	if err := r.requestOnDemandSnapshot(ctx, logger, etcd, svc); err != nil {
 		return nil, nil, err
 	}
-	svc, err := r.reconcileServices(ctx, logger, etcd, renderedChart)
-	if err != nil {
+
+	// The StatefulSet built below is governed by the peer Service, so it must exist first.
+	if _, err := r.reconcilePeerService(ctx, logger, etcd); err != nil {
 		return nil, nil, err
 	}
-	if svc != nil {
-		values["serviceName"] = svc.Name
+
+	if _, err := r.reconcileConfigMaps(ctx, logger, etcd); err != nil {
+		return nil, nil, err
 	}
 
-	cm, err := r.reconcileConfigMaps(ctx, logger, etcd, renderedChart)
+	ss, err := r.reconcileStatefulSet(ctx, logger, etcd)
 	if err != nil {
 		return nil, nil, err
 	}
-	if cm != nil {
-		values["configMapName"] = cm.Name
-	}
 
-	ss, err := r.reconcileStatefulSet(ctx, logger, etcd, values)
-	if err != nil {
+	if err := r.reconcilePodDisruptionBudget(ctx, logger, etcd); err != nil {
 		return nil, nil, err
 	}
 
 	return svc, ss, nil
 }
 
+// reconcilePodDisruptionBudget creates, updates, or (if disruption budget management has been
+// disabled via Spec.DisruptionBudget.Enabled) deletes the PodDisruptionBudget protecting this
+// Etcd cluster's pods from voluntary disruption.
+func (r *EtcdReconciler) reconcilePodDisruptionBudget(ctx context.Context, logger logr.Logger, etcd *druidv1alpha1.Etcd) error {
+	logger.Info("Reconciling etcd poddisruptionbudget")
+
+	desired, err := factory.BuildPodDisruptionBudget(etcd)
+	if err != nil {
+		return err
+	}
+
+	pdb := &policyv1.PodDisruptionBudget{}
+	err = r.Get(ctx, types.NamespacedName{Name: fmt.Sprintf("%s-pdb", etcd.Name), Namespace: etcd.Namespace}, pdb)
+	if apierrors.IsNotFound(err) {
+		if desired == nil {
+			return nil
+		}
+		if err := controllerutil.SetControllerReference(etcd, desired, r.Scheme); err != nil {
+			return err
+		}
+		return r.Create(ctx, desired)
+	}
+	if err != nil {
+		return err
+	}
+
+	if desired == nil {
+		logger.Info("Disruption budget management disabled, deleting poddisruptionbudget", "poddisruptionbudget", kutil.Key(pdb.Namespace, pdb.Name).String())
+		return client.IgnoreNotFound(r.Delete(ctx, pdb))
+	}
+
+	if reflect.DeepEqual(pdb.Spec, desired.Spec) {
+		return nil
+	}
+	pdbCopy := pdb.DeepCopy()
+	pdbCopy.Spec = desired.Spec
+	return retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		return r.Patch(ctx, pdbCopy, client.MergeFrom(pdb))
+	})
+}
+
 func checkEtcdOwnerReference(refs []metav1.OwnerReference, etcd *druidv1alpha1.Etcd) bool {
 	for _, ownerRef := range refs {
 		if ownerRef.UID == etcd.UID {
@@ -841,10 +1320,10 @@ func (r *EtcdReconciler) getMapFromEtcd(etcd *druidv1alpha1.Etcd) (map[string]in
 		}
 	}
 
-	var statefulsetReplicas int
-	if etcd.Spec.Replicas != 0 {
-		statefulsetReplicas = 1
-	}
+	// statefulsetReplicas mirrors BuildStatefulSet's own replica count, so a caller validating
+	// this legacy values map catches an image resolution problem for the same replica count the
+	// StatefulSet will actually be built with, including scale-to-zero.
+	statefulsetReplicas := factory.EffectiveReplicas(etcd)
 
 	etcdValues := map[string]interface{}{
 		"defragmentationSchedule": etcd.Spec.Etcd.DefragmentationSchedule,
@@ -973,6 +1452,7 @@ func (r *EtcdReconciler) getMapFromEtcd(etcd *druidv1alpha1.Etcd) (map[string]in
 		"replicas":                etcd.Spec.Replicas,
 		"statefulsetReplicas":     statefulsetReplicas,
 		"serviceName":             fmt.Sprintf("%s-client", etcd.Name),
+		"peerServiceName":         fmt.Sprintf("%s-peer", etcd.Name),
 		"configMapName":           fmt.Sprintf("etcd-bootstrap-%s", string(etcd.UID[:6])),
 		"volumeClaimTemplateName": volumeClaimTemplateName,
 	}
@@ -993,22 +1473,56 @@ func (r *EtcdReconciler) getMapFromEtcd(etcd *druidv1alpha1.Etcd) (map[string]in
 		values["tlsServerSecret"] = etcd.Spec.Etcd.TLS.ServerTLSSecretRef.Name
 		values["tlsClientSecret"] = etcd.Spec.Etcd.TLS.ClientTLSSecretRef.Name
 		values["tlsCASecret"] = etcd.Spec.Etcd.TLS.TLSCASecretRef.Name
+		// Peer (member-to-member) communication reuses the server TLS certificate, the same way
+		// BuildConfigMap's initial-cluster rendering picks https over http based on this same
+		// field: this API has no separate peer TLS config, since etcd's own server certificate
+		// already covers both roles.
+		values["tlsPeerSecret"] = etcd.Spec.Etcd.TLS.ServerTLSSecretRef.Name
 	}
 
 	if etcd.Spec.Backup.Store != nil {
-		storageProvider, err := utils.StorageProviderFromInfraProvider(etcd.Spec.Backup.Store.Provider)
-		if err != nil {
-			return nil, err
+		store := etcd.Spec.Backup.Store
+
+		// S3Compatible and Local are druid-native provider names, not cloud infra providers, so
+		// they bypass StorageProviderFromInfraProvider's aws/gcp/azure/... mapping and are passed
+		// through to the backup-restore sidecar as-is.
+		var storageProvider interface{}
+		switch {
+		case store.Provider != nil && *store.Provider == druidv1alpha1.S3CompatibleStorageProvider:
+			storageProvider = string(druidv1alpha1.S3CompatibleStorageProvider)
+		case store.Provider != nil && *store.Provider == druidv1alpha1.LocalStorageProvider:
+			storageProvider = string(druidv1alpha1.LocalStorageProvider)
+		default:
+			var err error
+			storageProvider, err = utils.StorageProviderFromInfraProvider(store.Provider)
+			if err != nil {
+				return nil, err
+			}
 		}
+
 		storeValues := map[string]interface{}{
-			"storePrefix":     etcd.Spec.Backup.Store.Prefix,
+			"storePrefix":     store.Prefix,
 			"storageProvider": storageProvider,
 		}
-		if etcd.Spec.Backup.Store.Container != nil {
-			storeValues["storageContainer"] = etcd.Spec.Backup.Store.Container
+		if store.Container != nil {
+			storeValues["storageContainer"] = store.Container
+		}
+		if store.SecretRef != nil {
+			storeValues["storeSecret"] = store.SecretRef.Name
 		}
-		if etcd.Spec.Backup.Store.SecretRef != nil {
-			storeValues["storeSecret"] = etcd.Spec.Backup.Store.SecretRef.Name
+		if store.S3Compatible != nil {
+			storeValues["s3Endpoint"] = store.S3Compatible.Endpoint
+			storeValues["s3Region"] = store.S3Compatible.Region
+			storeValues["s3ForcePathStyle"] = store.S3Compatible.ForcePathStyle
+			if store.S3Compatible.SSECSecretRef != nil {
+				storeValues["s3SSECSecret"] = store.S3Compatible.SSECSecretRef.Name
+			}
+			if store.S3Compatible.SSEKMSKeyID != nil {
+				storeValues["s3SSEKMSKeyID"] = *store.S3Compatible.SSEKMSKeyID
+			}
+		}
+		if store.Local != nil {
+			storeValues["localHostPath"] = store.Local.HostPath
 		}
 
 		values["store"] = storeValues
@@ -1108,6 +1622,29 @@ func (r *EtcdReconciler) removeDependantStatefulset(ctx context.Context, logger
 	return nil
 }
 
+// removeDependantPodDisruptionBudget deletes the PodDisruptionBudget owned by etcd, the same way
+// removeDependantStatefulset cleans up the StatefulSet, so neither object outlives its Etcd.
+func (r *EtcdReconciler) removeDependantPodDisruptionBudget(ctx context.Context, logger logr.Logger, etcd *druidv1alpha1.Etcd) error {
+	selector, err := metav1.LabelSelectorAsSelector(etcd.Spec.Selector)
+	if err != nil {
+		return err
+	}
+
+	pdbs := &policyv1.PodDisruptionBudgetList{}
+	if err := r.List(ctx, pdbs, client.InNamespace(etcd.Namespace), client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return err
+	}
+	for _, pdb := range pdbs.Items {
+		if checkEtcdOwnerReference(pdb.GetOwnerReferences(), etcd) || checkEtcdAnnotations(pdb.GetAnnotations(), etcd) {
+			logger.Info("Deleting poddisruptionbudget", "poddisruptionbudget", kutil.Key(pdb.GetNamespace(), pdb.GetName()).String())
+			if err := r.Delete(ctx, &pdb); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
 func canDeleteStatefulset(sts *appsv1.StatefulSet, etcd *druidv1alpha1.Etcd) bool {
 	// Adding check for ownerReference to have the same delete path for statefulset.
 	// The statefulset with ownerReference will be deleted automatically when etcd is
@@ -1136,14 +1673,34 @@ func (r *EtcdReconciler) updateEtcdErrorStatus(ctx context.Context, etcd *druidv
 	return r.removeOperationAnnotation(ctx, etcd)
 }
 
-func (r *EtcdReconciler) updateEtcdStatus(ctx context.Context, etcd *druidv1alpha1.Etcd, svc *corev1.Service, sts *appsv1.StatefulSet) error {
+func (r *EtcdReconciler) updateEtcdStatus(ctx context.Context, etcd *druidv1alpha1.Etcd, svc *corev1.Service, sts *appsv1.StatefulSet, members []druidv1alpha1.EtcdMemberStatus, leaderID string, allMembersReady bool) error {
 	err := kutil.TryUpdateStatus(ctx, retry.DefaultBackoff, r.Client, etcd, func() error {
 		ready := CheckStatefulSet(etcd, sts) == nil
+		if members != nil {
+			// A StatefulSet can report every replica ready while the cluster itself has lost
+			// quorum or its leader (e.g. mid rolling-update); gate on both rather than treating
+			// Kubernetes-level readiness as sufficient on its own for a multi-member cluster.
+			ready = ready && quorate(members) && leaderID != ""
+		}
 		etcd.Status.Ready = &ready
 		svcName := svc.Name
 		etcd.Status.ServiceName = &svcName
 		etcd.Status.LastError = nil
 		etcd.Status.ObservedGeneration = &etcd.Generation
+		if members != nil {
+			etcd.Status.Members = members
+			if leaderID != "" {
+				etcd.Status.LeaderID = &leaderID
+			}
+			etcd.Status.Conditions = mergeConditions(etcd.Status.Conditions, druidv1alpha1.ConditionOwnerDruid,
+				druidv1alpha1.Condition{
+					Type:    druidv1alpha1.ConditionTypeAllMembersReady,
+					Status:  conditionStatusFromBool(allMembersReady),
+					Reason:  "EtcdMemberStatusProbed",
+					Message: "Member readiness derived from the etcd client API during reconciliation.",
+				},
+			)
+		}
 		return nil
 	})
 
@@ -1153,6 +1710,15 @@ func (r *EtcdReconciler) updateEtcdStatus(ctx context.Context, etcd *druidv1alph
 	return r.removeOperationAnnotation(ctx, etcd)
 }
 
+// updateEtcdRestoreStatus records the progress of a Spec.Restore-driven bootstrap in
+// Status.RestorePhase, mirroring updateEtcdStatus's use of kutil.TryUpdateStatus.
+func (r *EtcdReconciler) updateEtcdRestoreStatus(ctx context.Context, etcd *druidv1alpha1.Etcd, phase druidv1alpha1.RestorePhase) error {
+	return kutil.TryUpdateStatus(ctx, retry.DefaultBackoff, r.Client, etcd, func() error {
+		etcd.Status.RestorePhase = &phase
+		return nil
+	})
+}
+
 func (r *EtcdReconciler) waitUntilStatefulSetReady(ctx context.Context, logger logr.Logger, etcd *druidv1alpha1.Etcd, sts *appsv1.StatefulSet) (*appsv1.StatefulSet, error) {
 	var (
 		ss = &appsv1.StatefulSet{}
@@ -1214,11 +1780,14 @@ func (r *EtcdReconciler) fetchPVCEventsFor(ctx context.Context, ss *appsv1.State
 }
 
 func (r *EtcdReconciler) removeOperationAnnotation(ctx context.Context, etcd *druidv1alpha1.Etcd) error {
-	if _, ok := etcd.Annotations[v1beta1constants.GardenerOperation]; ok {
-		delete(etcd.Annotations, v1beta1constants.GardenerOperation)
-		return r.Update(ctx, etcd)
+	_, hasGardenerOperation := etcd.Annotations[v1beta1constants.GardenerOperation]
+	_, hasSnapshotOperation := etcd.Annotations[SnapshotOperationAnnotation]
+	if !hasGardenerOperation && !hasSnapshotOperation {
+		return nil
 	}
-	return nil
+	delete(etcd.Annotations, v1beta1constants.GardenerOperation)
+	delete(etcd.Annotations, SnapshotOperationAnnotation)
+	return r.Update(ctx, etcd)
 }
 
 func (r *EtcdReconciler) updateEtcdStatusAsNotReady(ctx context.Context, etcd *druidv1alpha1.Etcd) (*druidv1alpha1.Etcd, error) {
@@ -1266,11 +1835,23 @@ func (r *EtcdReconciler) claimConfigMaps(ctx context.Context, etcd *druidv1alpha
 	return cm.ClaimConfigMaps(ctx, configMaps)
 }
 
+// hasSnapshotOperationAnnotation triggers a reconcile whenever SnapshotOperationAnnotation is set
+// to SnapshotOperationValue on an Etcd, so an on-demand snapshot request is handled immediately
+// rather than waiting for some unrelated spec change or reconcile failure to also satisfy
+// GenerationChangedPredicate / LastOperationNotSuccessful. It lives here rather than in
+// pkg/predicate since it is specific to this controller's own annotation.
+func hasSnapshotOperationAnnotation() predicate.Predicate {
+	return predicate.NewPredicateFuncs(func(obj client.Object) bool {
+		return obj.GetAnnotations()[SnapshotOperationAnnotation] == SnapshotOperationValue
+	})
+}
+
 // SetupWithManager sets up manager with a new controller and r as the reconcile.Reconciler
 func (r *EtcdReconciler) SetupWithManager(mgr ctrl.Manager, workers int, ignoreOperationAnnotation bool) error {
 	predicates := []predicate.Predicate{
 		druidpredicates.GenerationChangedPredicate{},
 		druidpredicates.LastOperationNotSuccessful(),
+		hasSnapshotOperationAnnotation(),
 	}
 	builder := ctrl.NewControllerManagedBy(mgr).WithOptions(controller.Options{
 		MaxConcurrentReconciles: workers,
@@ -1282,7 +1863,8 @@ func (r *EtcdReconciler) SetupWithManager(mgr ctrl.Manager, workers int, ignoreO
 	if ignoreOperationAnnotation {
 		builder = builder.Owns(&v1.Service{}).
 			Owns(&v1.ConfigMap{}).
-			Owns(&appsv1.StatefulSet{})
+			Owns(&appsv1.StatefulSet{}).
+			Owns(&policyv1.PodDisruptionBudget{})
 	}
 	return builder.Complete(r)
 }
@@ -17,44 +17,82 @@ package controllers
 import (
 	"context"
 	"fmt"
+	"sync"
 	"time"
 
 	extensionshandler "github.com/gardener/gardener/extensions/pkg/handler"
 	kutil "github.com/gardener/gardener/pkg/utils/kubernetes"
 	"github.com/go-logr/logr"
 	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/retry"
 	"k8s.io/utils/pointer"
 	ctrl "sigs.k8s.io/controller-runtime"
 	ctrlbuilder "sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	druidv1alpha1 "github.com/gardener/etcd-druid/api/v1alpha1"
+	"github.com/gardener/etcd-druid/pkg/etcdclient"
 	druidmapper "github.com/gardener/etcd-druid/pkg/mapper"
 	druidpredicates "github.com/gardener/etcd-druid/pkg/predicate"
 )
 
+// DefaultEtcdConnectionTimeout is the default timeout for dialing the etcd cluster and for
+// keep-alive probes on the connection.
+const DefaultEtcdConnectionTimeout = 5 * time.Second
+
+// DefaultEtcdStatusSyncPeriod is the default requeue interval used to keep Etcd status in sync
+// with the live cluster when no other event triggers a reconciliation.
+const DefaultEtcdStatusSyncPeriod = 30 * time.Second
+
+// MemberRemovalFinalizer is placed by druid on every etcd pod so that the StatefulSet
+// controller's deletion of a pod actually blocks (by leaving the pod in Terminating state with
+// DeletionTimestamp set) until EtcdCustodian has removed the corresponding member from the
+// cluster and released the finalizer.
+const MemberRemovalFinalizer = "member-removal.druid.gardener.cloud"
+
 // EtcdCustodian reconciles status of Etcd object
 type EtcdCustodian struct {
 	client.Client
-	Scheme *runtime.Scheme
-	logger logr.Logger
+	Scheme                   *runtime.Scheme
+	logger                   logr.Logger
+	etcdClientFactory        *etcdclient.Factory
+	statusSyncPeriod         time.Duration
+	recorder                 record.EventRecorder
+	backupStatusPollInterval time.Duration
+	backupStatusTimeout      time.Duration
+
+	defragMu   sync.Mutex
+	lastDefrag map[types.UID]time.Time
 }
 
 // NewEtcdCustodian creates a new EtcdCustodian object
 func NewEtcdCustodian(mgr manager.Manager) *EtcdCustodian {
+	return NewEtcdCustodianWithConfig(mgr, DefaultEtcdConnectionTimeout, DefaultEtcdStatusSyncPeriod, DefaultBackupStatusPollInterval, DefaultBackupStatusTimeout)
+}
+
+// NewEtcdCustodianWithConfig creates a new EtcdCustodian object with the given etcd client
+// connection timeout, status sync period, and backup-restore leader polling parameters.
+func NewEtcdCustodianWithConfig(mgr manager.Manager, etcdConnectionTimeout, statusSyncPeriod, backupStatusPollInterval, backupStatusTimeout time.Duration) *EtcdCustodian {
 	return &EtcdCustodian{
-		Client: mgr.GetClient(),
-		Scheme: mgr.GetScheme(),
-		logger: log.Log.WithName("custodian-controller"),
+		Client:                   mgr.GetClient(),
+		Scheme:                   mgr.GetScheme(),
+		logger:                   log.Log.WithName("custodian-controller"),
+		etcdClientFactory:        etcdclient.NewFactory(mgr.GetClient(), etcdConnectionTimeout),
+		statusSyncPeriod:         statusSyncPeriod,
+		recorder:                 mgr.GetEventRecorderFor("etcd-custodian-controller"),
+		backupStatusPollInterval: backupStatusPollInterval,
+		backupStatusTimeout:      backupStatusTimeout,
 	}
 }
 
@@ -126,29 +164,438 @@ func (ec *EtcdCustodian) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.
 	}
 
 	if err := ec.updateEtcdStatus(ctx, logger, etcd, &stsList.Items[0]); err != nil {
+		if etcdclient.IsConnectionError(err) {
+			logger.Info(fmt.Sprintf("Requeue with backoff, could not reach etcd cluster: %v", err))
+			return ctrl.Result{RequeueAfter: ec.statusSyncPeriod}, nil
+		}
 		return ctrl.Result{}, err
 	}
 
-	return ctrl.Result{}, nil
+	if err := ec.ensureMemberRemovalFinalizers(ctx, etcd, &stsList.Items[0]); err != nil {
+		logger.Error(err, "Error while ensuring member-removal finalizers")
+		return ctrl.Result{RequeueAfter: 5 * time.Second}, nil
+	}
+
+	if err := ec.releaseFinalizersForRestartingPods(ctx, etcd, &stsList.Items[0]); err != nil {
+		logger.Error(err, "Error while releasing member-removal finalizers for restarting pods")
+		return ctrl.Result{RequeueAfter: 5 * time.Second}, nil
+	}
+
+	if err := ec.reconcileScaleDown(ctx, logger, etcd, &stsList.Items[0]); err != nil {
+		logger.Error(err, "Error while reconciling etcd member scale-down")
+		return ctrl.Result{RequeueAfter: 5 * time.Second}, nil
+	}
+
+	if err := ec.reconcileAlarms(ctx, logger, etcd, &stsList.Items[0]); err != nil {
+		logger.Error(err, "Error while reconciling etcd alarms")
+	}
+
+	if etcd.Spec.Backup.Store != nil {
+		if err := ec.updateBackupLeaderStatus(ctx, logger, etcd, &stsList.Items[0]); err != nil {
+			logger.Error(err, "Error while polling backup-restore leader status")
+		}
+	}
+
+	requeueAfter := ec.statusSyncPeriod
+	if etcd.Spec.Backup.Store != nil && ec.backupStatusPollInterval < requeueAfter {
+		requeueAfter = ec.backupStatusPollInterval
+	}
+	return ctrl.Result{RequeueAfter: requeueAfter}, nil
+}
+
+// ensureMemberRemovalFinalizers places MemberRemovalFinalizer on every currently existing etcd
+// pod. It runs on every reconcile, independent of whether a scale-down is in progress, because a
+// finalizer only blocks deletion if it is already present at the moment the StatefulSet
+// controller issues the delete - adding it reactively once a pod is already Terminating would be
+// too late for a pod that had no finalizers at all.
+func (ec *EtcdCustodian) ensureMemberRemovalFinalizers(ctx context.Context, etcd *druidv1alpha1.Etcd, sts *appsv1.StatefulSet) error {
+	replicas := int(sts.Status.Replicas)
+	for ordinal := 0; ordinal < replicas; ordinal++ {
+		podName := fmt.Sprintf("%s-%d", etcd.Name, ordinal)
+		pod := &corev1.Pod{}
+		if err := ec.Get(ctx, types.NamespacedName{Name: podName, Namespace: etcd.Namespace}, pod); err != nil {
+			if errors.IsNotFound(err) {
+				continue
+			}
+			return err
+		}
+		if pod.DeletionTimestamp != nil || controllerutil.ContainsFinalizer(pod, MemberRemovalFinalizer) {
+			continue
+		}
+		podCopy := pod.DeepCopy()
+		controllerutil.AddFinalizer(podCopy, MemberRemovalFinalizer)
+		if err := ec.Patch(ctx, podCopy, client.MergeFrom(pod)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// releaseFinalizersForRestartingPods releases MemberRemovalFinalizer for any pod within the
+// current replica range (ordinal < Spec.Replicas) that has already started terminating, e.g.
+// recreated by a rolling update or deleted directly by EtcdReconciler's crashloop remediation.
+// Unlike reconcileScaleDown, it never calls MemberRemove: these pods are expected to restart and
+// rejoin the cluster under their existing member identity, so the member must stay registered.
+// Without this, ensureMemberRemovalFinalizers' unconditional finalizer would wedge such a pod in
+// Terminating forever, since reconcileScaleDown only ever looks at ordinals being scaled away.
+func (ec *EtcdCustodian) releaseFinalizersForRestartingPods(ctx context.Context, etcd *druidv1alpha1.Etcd, sts *appsv1.StatefulSet) error {
+	desiredReplicas := etcd.Spec.Replicas
+	observedReplicas := int(sts.Status.Replicas)
+	limit := desiredReplicas
+	if observedReplicas < limit {
+		limit = observedReplicas
+	}
+
+	for ordinal := 0; ordinal < limit; ordinal++ {
+		podName := fmt.Sprintf("%s-%d", etcd.Name, ordinal)
+		pod := &corev1.Pod{}
+		if err := ec.Get(ctx, types.NamespacedName{Name: podName, Namespace: etcd.Namespace}, pod); err != nil {
+			if errors.IsNotFound(err) {
+				continue
+			}
+			return err
+		}
+		if pod.DeletionTimestamp == nil {
+			continue
+		}
+		if err := ec.releaseMemberRemovalFinalizer(ctx, pod); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// reconcileScaleDown removes the etcd cluster member corresponding to every pod whose ordinal is
+// at or beyond the desired replica count, once that pod has actually started terminating. It
+// relies on MemberRemovalFinalizer (placed by ensureMemberRemovalFinalizers ahead of time) to
+// hold the pod in the Terminating state with DeletionTimestamp set, so a Raft member is never
+// removed from a pod that is merely being recreated for a rolling update and never started
+// terminating in the first place.
+func (ec *EtcdCustodian) reconcileScaleDown(ctx context.Context, logger logr.Logger, etcd *druidv1alpha1.Etcd, sts *appsv1.StatefulSet) error {
+	desiredReplicas := etcd.Spec.Replicas
+	observedReplicas := int(sts.Status.Replicas)
+	if desiredReplicas >= observedReplicas {
+		return nil
+	}
+
+	clientPort := int32(2379)
+	if etcd.Spec.Etcd.ClientPort != nil {
+		clientPort = *etcd.Spec.Etcd.ClientPort
+	}
+	serviceName := fmt.Sprintf("%s-client", etcd.Name)
+	if etcd.Status.ServiceName != nil {
+		serviceName = *etcd.Status.ServiceName
+	}
+
+	cl, err := ec.etcdClientFactory.NewClient(ctx, etcd, serviceName, clientPort, observedReplicas)
+	if err != nil {
+		return fmt.Errorf("unable to create etcd client: %w", err)
+	}
+	defer cl.Close()
+
+	members, err := cl.MemberList(ctx)
+	if err != nil {
+		return err
+	}
+	memberByName := map[string]etcdclient.Member{}
+	for _, m := range members {
+		memberByName[m.Name] = m
+	}
+
+	for ordinal := observedReplicas - 1; ordinal >= desiredReplicas; ordinal-- {
+		podName := fmt.Sprintf("%s-%d", etcd.Name, ordinal)
+
+		pod := &corev1.Pod{}
+		if err := ec.Get(ctx, types.NamespacedName{Name: podName, Namespace: etcd.Namespace}, pod); err != nil {
+			if errors.IsNotFound(err) {
+				continue
+			}
+			return err
+		}
+
+		if pod.DeletionTimestamp == nil {
+			// The StatefulSet controller has not yet started terminating this pod; nothing to
+			// coordinate yet. ensureMemberRemovalFinalizers already guarantees the finalizer is
+			// in place for when it does.
+			continue
+		}
+
+		member, stillMember := memberByName[podName]
+		if !stillMember {
+			if err := ec.releaseMemberRemovalFinalizer(ctx, pod); err != nil {
+				return err
+			}
+			continue
+		}
+
+		ec.recorder.Eventf(etcd, corev1.EventTypeNormal, "MemberRemovalPending", "Removing member %s (%s) from the etcd cluster before scaling down", member.Name, member.ID)
+		logger.Info("Pod is terminating, removing member from cluster", "pod", podName, "member", member.ID)
+		if err := ec.setMemberRemovalPending(ctx, etcd, true, member.Name); err != nil {
+			return err
+		}
+
+		if err := cl.MemberRemove(ctx, member.ID); err != nil {
+			return fmt.Errorf("unable to remove member %s (%s): %w", member.Name, member.ID, err)
+		}
+
+		remaining, err := cl.MemberList(ctx)
+		if err != nil {
+			return err
+		}
+		if !containsPeerURLs(remaining, member.PeerURLs) {
+			if err := ec.releaseMemberRemovalFinalizer(ctx, pod); err != nil {
+				return err
+			}
+			ec.recorder.Eventf(etcd, corev1.EventTypeNormal, "MemberRemoved", "Member %s (%s) removed from the etcd cluster", member.Name, member.ID)
+			if err := ec.setMemberRemovalPending(ctx, etcd, false, member.Name); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// setMemberRemovalPending records whether a member removal is currently blocking pod deletion.
+func (ec *EtcdCustodian) setMemberRemovalPending(ctx context.Context, etcd *druidv1alpha1.Etcd, pending bool, memberName string) error {
+	return kutil.TryUpdateStatus(ctx, retry.DefaultBackoff, ec.Client, etcd, func() error {
+		status := druidv1alpha1.ConditionFalse
+		reason := "NoRemovalPending"
+		message := "No member removal is currently pending."
+		if pending {
+			status = druidv1alpha1.ConditionTrue
+			reason = "MemberRemovalPending"
+			message = fmt.Sprintf("Waiting for member %s to be removed from the etcd cluster.", memberName)
+		}
+		etcd.Status.Conditions = mergeConditions(etcd.Status.Conditions, druidv1alpha1.ConditionOwnerDruid, druidv1alpha1.Condition{
+			Type:    druidv1alpha1.ConditionTypeMemberRemovalPending,
+			Status:  status,
+			Reason:  reason,
+			Message: message,
+		})
+		return nil
+	})
+}
+
+// releaseMemberRemovalFinalizer removes MemberRemovalFinalizer from pod, letting its deletion
+// proceed now that its etcd member has been removed from the cluster (or was already gone).
+func (ec *EtcdCustodian) releaseMemberRemovalFinalizer(ctx context.Context, pod *corev1.Pod) error {
+	if !controllerutil.ContainsFinalizer(pod, MemberRemovalFinalizer) {
+		return nil
+	}
+	podCopy := pod.DeepCopy()
+	controllerutil.RemoveFinalizer(podCopy, MemberRemovalFinalizer)
+	return ec.Patch(ctx, podCopy, client.MergeFrom(pod))
+}
+
+func containsPeerURLs(members []etcdclient.Member, peerURLs []string) bool {
+	for _, m := range members {
+		for _, url := range m.PeerURLs {
+			for _, departing := range peerURLs {
+				if url == departing {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// clusterHealth is the result of probing the etcd cluster via the etcd client API.
+type clusterHealth struct {
+	clusterSize int
+	quorate     bool
+	hasLeader   bool
+	members     []druidv1alpha1.EtcdMemberStatus
+}
+
+// probeCluster dials the etcd cluster behind the given StatefulSet and derives the cluster size,
+// quorum, and per-member status from MemberList and Status. It tolerates partial
+// failures: a member is only marked NotReady if its own endpoint could not be reached.
+func (ec *EtcdCustodian) probeCluster(ctx context.Context, logger logr.Logger, etcd *druidv1alpha1.Etcd, sts *appsv1.StatefulSet) (*clusterHealth, error) {
+	clientPort := int32(2379)
+	if etcd.Spec.Etcd.ClientPort != nil {
+		clientPort = *etcd.Spec.Etcd.ClientPort
+	}
+	serviceName := fmt.Sprintf("%s-client", etcd.Name)
+	if etcd.Status.ServiceName != nil {
+		serviceName = *etcd.Status.ServiceName
+	}
+
+	replicas := int(*sts.Spec.Replicas)
+	cl, err := ec.etcdClientFactory.NewClient(ctx, etcd, serviceName, clientPort, replicas)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create etcd client: %w", err)
+	}
+	defer cl.Close()
+
+	members, err := cl.MemberList(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	health := &clusterHealth{
+		clusterSize: len(members),
+		members:     make([]druidv1alpha1.EtcdMemberStatus, 0, len(members)),
+	}
+
+	previousReady := map[string]bool{}
+	previousTransition := map[string]metav1.Time{}
+	previousHeartbeat := map[string]metav1.Time{}
+	for _, m := range etcd.Status.Members {
+		if m.Ready != nil {
+			previousReady[m.Name] = *m.Ready
+		}
+		previousTransition[m.Name] = m.LastTransitionTime
+		previousHeartbeat[m.Name] = m.LastHeartbeatTime
+	}
+
+	readyCount := 0
+	for _, m := range members {
+		memberStatus := druidv1alpha1.EtcdMemberStatus{
+			Name:     m.Name,
+			ID:       m.ID,
+			PeerURLs: m.PeerURLs,
+		}
+
+		ready := false
+		for _, endpoint := range m.ClientURLs {
+			status, statusErr := cl.Status(ctx, endpoint)
+			if statusErr != nil {
+				logger.Info(fmt.Sprintf("could not fetch status for member %s (%s): %v", m.Name, m.ID, statusErr))
+				continue
+			}
+			ready = true
+			dbSize := status.DBSize
+			revision := status.Revision
+			memberStatus.DBSize = &dbSize
+			memberStatus.Revision = &revision
+			role := druidv1alpha1.Follower
+			if m.IsLearner {
+				role = druidv1alpha1.Learner
+			} else if status.Leader == status.MemberID {
+				role = druidv1alpha1.Leader
+				health.hasLeader = true
+			}
+			memberStatus.Role = &role
+			break
+		}
+
+		if ready {
+			readyCount++
+			memberStatus.Reason = "Healthy"
+		} else {
+			memberStatus.Reason = "EndpointUnreachable"
+		}
+		memberStatus.Ready = &ready
+		if ready {
+			memberStatus.LastHeartbeatTime = metav1.Now()
+		} else {
+			memberStatus.LastHeartbeatTime = previousHeartbeat[m.Name]
+		}
+		if wasReady, known := previousReady[m.Name]; known && wasReady == ready {
+			memberStatus.LastTransitionTime = previousTransition[m.Name]
+		} else {
+			memberStatus.LastTransitionTime = metav1.Now()
+		}
+		health.members = append(health.members, memberStatus)
+	}
+
+	health.quorate = health.clusterSize > 0 && readyCount >= (health.clusterSize/2)+1
+	return health, nil
+}
+
+// allMembersReady returns true if the cluster has at least one member and every member reports Ready.
+func allMembersReady(members []druidv1alpha1.EtcdMemberStatus) bool {
+	if len(members) == 0 {
+		return false
+	}
+	for _, m := range members {
+		if m.Ready == nil || !*m.Ready {
+			return false
+		}
+	}
+	return true
+}
+
+// mergeConditions returns existing with every condition owned by owner replaced by the matching
+// entry (by Type) in updates, or appended if no matching Type was present yet. Conditions owned
+// by other actors (e.g. etcd-backup-restore) are left untouched, fixing the historical behaviour
+// of unconditionally resetting the whole slice.
+func mergeConditions(existing []druidv1alpha1.Condition, owner string, updates ...druidv1alpha1.Condition) []druidv1alpha1.Condition {
+	byType := map[druidv1alpha1.ConditionType]druidv1alpha1.Condition{}
+	for _, c := range existing {
+		byType[c.Type] = c
+	}
+
+	for _, update := range updates {
+		previous, ok := byType[update.Type]
+		if ok && previous.Owner != "" && previous.Owner != owner {
+			continue
+		}
+		now := metav1.Now()
+		update.Owner = owner
+		update.LastUpdateTime = now
+		update.LastTransitionTime = now
+		if ok && previous.Status == update.Status {
+			update.LastTransitionTime = previous.LastTransitionTime
+		}
+		byType[update.Type] = update
+	}
+
+	merged := make([]druidv1alpha1.Condition, 0, len(byType))
+	for _, c := range byType {
+		merged = append(merged, c)
+	}
+	return merged
+}
+
+func conditionStatusFromBool(b bool) druidv1alpha1.ConditionStatus {
+	if b {
+		return druidv1alpha1.ConditionTrue
+	}
+	return druidv1alpha1.ConditionFalse
 }
 
 func (ec *EtcdCustodian) updateEtcdStatus(ctx context.Context, logger logr.Logger, etcd *druidv1alpha1.Etcd, sts *appsv1.StatefulSet) error {
 	logger.Info("Updating etcd status with statefulset information")
 
+	health, err := ec.probeCluster(ctx, logger, etcd, sts)
+	if err != nil {
+		return err
+	}
+
 	return kutil.TryUpdateStatus(ctx, retry.DefaultBackoff, ec.Client, etcd, func() error {
 		etcd.Status.Etcd = &druidv1alpha1.CrossVersionObjectReference{
 			APIVersion: sts.APIVersion,
 			Kind:       sts.Kind,
 			Name:       sts.Name,
 		}
-		ready := CheckStatefulSet(etcd, sts) == nil
+		stsReady := CheckStatefulSet(etcd, sts) == nil
+		ready := stsReady && health.quorate && health.hasLeader
 
-		// To be changed once we have multiple replicas.
 		etcd.Status.CurrentReplicas = sts.Status.CurrentReplicas
 		etcd.Status.ReadyReplicas = sts.Status.ReadyReplicas
 		etcd.Status.UpdatedReplicas = sts.Status.UpdatedReplicas
 		etcd.Status.Ready = &ready
-		logger.Info(fmt.Sprintf("ETCD status updated for statefulset current replicas: %v, ready replicas: %v, updated replicas: %v", sts.Status.CurrentReplicas, sts.Status.ReadyReplicas, sts.Status.UpdatedReplicas))
+		etcd.Status.ClusterSize = &health.clusterSize
+		etcd.Status.Quorate = &health.quorate
+		etcd.Status.Members = health.members
+		etcd.Status.Conditions = mergeConditions(etcd.Status.Conditions, druidv1alpha1.ConditionOwnerDruid,
+			druidv1alpha1.Condition{
+				Type:    druidv1alpha1.ConditionTypeAllMembersReady,
+				Status:  conditionStatusFromBool(allMembersReady(health.members)),
+				Reason:  "MembersStatus",
+				Message: "Aggregated readiness of all observed etcd members.",
+			},
+			druidv1alpha1.Condition{
+				Type:    druidv1alpha1.ConditionTypeQuorate,
+				Status:  conditionStatusFromBool(health.quorate && health.hasLeader),
+				Reason:  "QuorumCheck",
+				Message: "Whether the cluster has a quorum and a known leader.",
+			},
+		)
+		logger.Info(fmt.Sprintf("ETCD status updated for statefulset current replicas: %v, ready replicas: %v, updated replicas: %v, quorate: %v", sts.Status.CurrentReplicas, sts.Status.ReadyReplicas, sts.Status.UpdatedReplicas, health.quorate))
 		return nil
 	})
 }
@@ -157,11 +604,23 @@ func (ec *EtcdCustodian) updateEtcdStatusWithNoSts(ctx context.Context, logger l
 	logger.Info("Updating etcd status when no statefulset found")
 
 	if err := kutil.TryUpdateStatus(ctx, retry.DefaultBackoff, ec.Client, etcd, func() error {
-		// TODO: (timuthy) Don't reset all conditions as some of them will be maintained by other actors (e.g. etcd-backup-restore)
-		conditions := []druidv1alpha1.Condition{}
-		etcd.Status.Conditions = conditions
-
-		// To be changed once we have multiple replicas.
+		// Conditions owned by other actors (e.g. etcd-backup-restore's BackupReady) must survive
+		// even though druid itself has nothing to report right now.
+		etcd.Status.Conditions = mergeConditions(etcd.Status.Conditions, druidv1alpha1.ConditionOwnerDruid,
+			druidv1alpha1.Condition{
+				Type:    druidv1alpha1.ConditionTypeAllMembersReady,
+				Status:  druidv1alpha1.ConditionFalse,
+				Reason:  "NoStatefulSet",
+				Message: "No single StatefulSet could be associated with this Etcd resource.",
+			},
+			druidv1alpha1.Condition{
+				Type:    druidv1alpha1.ConditionTypeQuorate,
+				Status:  druidv1alpha1.ConditionFalse,
+				Reason:  "NoStatefulSet",
+				Message: "No single StatefulSet could be associated with this Etcd resource.",
+			},
+		)
+		etcd.Status.Members = nil
 		etcd.Status.CurrentReplicas = 0
 		etcd.Status.ReadyReplicas = 0
 		etcd.Status.UpdatedReplicas = 0
@@ -186,5 +645,10 @@ func (ec *EtcdCustodian) SetupWithManager(ctx context.Context, mgr ctrl.Manager,
 			extensionshandler.EnqueueRequestsFromMapper(druidmapper.StatefulSetToEtcd(ctx, mgr.GetClient()), extensionshandler.UpdateWithNew),
 			ctrlbuilder.WithPredicates(druidpredicates.StatefulSetStatusChange()),
 		).
+		Watches(
+			&source.Kind{Type: &corev1.Pod{}},
+			extensionshandler.EnqueueRequestsFromMapper(druidmapper.PodToEtcd(ctx, mgr.GetClient()), extensionshandler.UpdateWithNew),
+			ctrlbuilder.WithPredicates(druidpredicates.PodStatusChange()),
+		).
 		Complete(ec)
 }
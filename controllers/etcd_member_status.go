@@ -0,0 +1,132 @@
+// Copyright (c) 2021 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	druidv1alpha1 "github.com/gardener/etcd-druid/api/v1alpha1"
+	"github.com/gardener/etcd-druid/pkg/etcdclient"
+
+	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
+)
+
+// probeMembers queries the live etcd cluster behind sts via the etcd client API and returns the
+// per-member status to be written to etcd.Status.Members, together with whether every member
+// reported Ready. A non-nil error here always means the etcd client could not be created or
+// MemberList itself failed (e.g. the cluster is unreachable); callers should requeue with DefaultInterval rather than treat it as a hard
+// reconcile failure. A Status() failure for one endpoint only marks that one member NotReady -
+// it never fails the whole probe.
+func (r *EtcdReconciler) probeMembers(ctx context.Context, logger logr.Logger, etcd *druidv1alpha1.Etcd, sts *appsv1.StatefulSet) ([]druidv1alpha1.EtcdMemberStatus, string, bool, error) {
+	if sts == nil || sts.Status.ReadyReplicas == 0 {
+		return nil, "", false, nil
+	}
+
+	serviceName := fmt.Sprintf("%s-client", etcd.Name)
+	if etcd.Status.ServiceName != nil {
+		serviceName = *etcd.Status.ServiceName
+	}
+	clientPort := int32(2379)
+	if etcd.Spec.Etcd.ClientPort != nil {
+		clientPort = *etcd.Spec.Etcd.ClientPort
+	}
+	replicas := etcd.Spec.Replicas
+	if replicas == 0 {
+		replicas = 1
+	}
+
+	cli, err := r.EtcdClientFactory.NewClient(ctx, etcd, serviceName, clientPort, replicas)
+	if err != nil {
+		return nil, "", false, err
+	}
+	defer func() {
+		if err := cli.Close(); err != nil {
+			logger.Error(err, "Error closing etcd client")
+		}
+	}()
+
+	clusterMembers, err := cli.MemberList(ctx)
+	if err != nil {
+		return nil, "", false, err
+	}
+
+	var leaderID string
+	members := make([]druidv1alpha1.EtcdMemberStatus, 0, len(clusterMembers))
+	for _, m := range clusterMembers {
+		member := druidv1alpha1.EtcdMemberStatus{
+			Name:      m.Name,
+			ID:        m.ID,
+			PeerURLs:  m.PeerURLs,
+			IsLearner: m.IsLearner,
+		}
+
+		var status *etcdclient.EndpointStatus
+		for _, endpoint := range m.ClientURLs {
+			s, statusErr := cli.Status(ctx, endpoint)
+			if statusErr == nil {
+				status = s
+				break
+			}
+			logger.Info("Unable to fetch status for etcd member endpoint", "member", m.Name, "endpoint", endpoint, "error", statusErr.Error())
+		}
+
+		ready := status != nil
+		member.Ready = &ready
+		if status != nil {
+			dbSize := status.DBSize
+			member.DBSize = &dbSize
+			revision := status.Revision
+			member.Revision = &revision
+			raftIndex := int64(status.RaftIndex)
+			member.RaftIndex = &raftIndex
+			if status.Leader != "" {
+				leaderID = status.Leader
+			}
+			role := druidv1alpha1.Follower
+			if status.MemberID == status.Leader {
+				role = druidv1alpha1.Leader
+			} else if status.IsLearner {
+				role = druidv1alpha1.Learner
+			}
+			member.Role = &role
+		} else {
+			member.Reason = "EndpointUnreachable"
+		}
+
+		members = append(members, member)
+	}
+
+	return members, leaderID, allMembersReady(members), nil
+}
+
+// quorate returns true if a strict majority of voting (non-learner) members report Ready. Unlike
+// allMembersReady, it tolerates a minority of members being unreachable (e.g. during a rolling
+// update). It differs from EtcdCustodian's quorum computation in probeCluster, which counts
+// learners in both the cluster size and the ready count.
+func quorate(members []druidv1alpha1.EtcdMemberStatus) bool {
+	voters, ready := 0, 0
+	for _, m := range members {
+		if m.IsLearner {
+			continue
+		}
+		voters++
+		if m.Ready != nil && *m.Ready {
+			ready++
+		}
+	}
+	return voters > 0 && ready >= (voters/2)+1
+}
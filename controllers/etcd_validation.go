@@ -0,0 +1,50 @@
+// Copyright (c) 2021 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"fmt"
+
+	druidv1alpha1 "github.com/gardener/etcd-druid/api/v1alpha1"
+)
+
+// validateBackupStore performs admission-style validation of Spec.Backup.Store that cannot be
+// expressed through the CRD's OpenAPI schema alone, e.g. fields that are only meaningful for a
+// particular Provider or are mutually exclusive with one another. It is invoked from reconcile
+// before reconcileEtcd, so an invalid store configuration is rejected before any object is
+// created or patched.
+func validateBackupStore(etcd *druidv1alpha1.Etcd) error {
+	store := etcd.Spec.Backup.Store
+	if store == nil || store.Provider == nil {
+		return nil
+	}
+
+	switch *store.Provider {
+	case druidv1alpha1.S3CompatibleStorageProvider:
+		s3 := store.S3Compatible
+		if s3 == nil || len(s3.Endpoint) == 0 {
+			return fmt.Errorf("spec.backup.store.s3Compatible.endpoint is required for provider %q", *store.Provider)
+		}
+		if s3.SSEKMSKeyID != nil && s3.SSECSecretRef != nil {
+			return fmt.Errorf("spec.backup.store.s3Compatible.sseKMSKeyID and sseCSecretRef are mutually exclusive")
+		}
+	case druidv1alpha1.LocalStorageProvider:
+		if store.Local == nil || len(store.Local.HostPath) == 0 {
+			return fmt.Errorf("spec.backup.store.local.hostPath is required for provider %q", *store.Provider)
+		}
+	}
+
+	return nil
+}
@@ -18,9 +18,12 @@ package main
 import (
 	"flag"
 	"os"
+	"time"
 
 	druidv1alpha1 "github.com/gardener/etcd-druid/api/v1alpha1"
 	"github.com/gardener/etcd-druid/controllers"
+	"github.com/gardener/etcd-druid/pkg/controllers/bundle"
+	"github.com/gardener/etcd-druid/pkg/controllers/etcdbackup"
 
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
@@ -46,13 +49,22 @@ func init() {
 
 func main() {
 	var (
-		metricsAddr                string
-		enableLeaderElection       bool
-		leaderElectionID           string
-		leaderElectionResourceLock string
-		etcdWorkers                int
-		custodianWorkers           int
-		ignoreOperationAnnotation  bool
+		metricsAddr                   string
+		enableLeaderElection          bool
+		leaderElectionID              string
+		leaderElectionResourceLock    string
+		etcdWorkers                   int
+		custodianWorkers              int
+		etcdBackupWorkers             int
+		bundleWorkers                 int
+		ignoreOperationAnnotation     bool
+		etcdConnectionTimeout         time.Duration
+		etcdStatusSyncPeriod          time.Duration
+		backupStatusPollInterval      time.Duration
+		backupStatusTimeout           time.Duration
+		backupRequestTimeout          time.Duration
+		crashLoopRemediationCooldown  time.Duration
+		maxConcurrentCrashLoopDeletes int
 
 		// TODO: migrate default to `leases` in one of the next releases
 		defaultLeaderElectionResourceLock = resourcelock.ConfigMapsLeasesResourceLock
@@ -61,6 +73,8 @@ func main() {
 
 	flag.IntVar(&etcdWorkers, "workers", 3, "Number of worker threads of the etcd controller.")
 	flag.IntVar(&custodianWorkers, "custodian-workers", 3, "Number of worker threads of the custodian controller.")
+	flag.IntVar(&etcdBackupWorkers, "etcd-backup-workers", 3, "Number of worker threads of the etcd backup controller.")
+	flag.IntVar(&bundleWorkers, "bundle-workers", 3, "Number of worker threads of each etcd resource bundle state controller.")
 	flag.StringVar(&metricsAddr, "metrics-addr", ":8080", "The address the metric endpoint binds to.")
 	flag.BoolVar(&enableLeaderElection, "enable-leader-election", false,
 		"Enable leader election for controller manager. Enabling this will ensure there is only one active controller manager.")
@@ -69,6 +83,13 @@ func main() {
 	flag.StringVar(&leaderElectionResourceLock, "leader-election-resource-lock", defaultLeaderElectionResourceLock, "Which resource type to use for leader election. "+
 		"Supported options are 'endpoints', 'configmaps', 'leases', 'endpointsleases' and 'configmapsleases'.")
 	flag.BoolVar(&ignoreOperationAnnotation, "ignore-operation-annotation", true, "Ignore the operation annotation or not.")
+	flag.DurationVar(&etcdConnectionTimeout, "etcd-connection-timeout", controllers.DefaultEtcdConnectionTimeout, "Timeout for dialing the etcd cluster and for keep-alive probes on the connection when probing its health.")
+	flag.DurationVar(&etcdStatusSyncPeriod, "etcd-status-sync-period", controllers.DefaultEtcdStatusSyncPeriod, "Period after which the Etcd status is re-synced with the live etcd cluster.")
+	flag.DurationVar(&backupStatusPollInterval, "backup-status-poll-interval", controllers.DefaultBackupStatusPollInterval, "Interval at which the backup-restore sidecar's leader election endpoint is polled.")
+	flag.DurationVar(&backupStatusTimeout, "backup-status-timeout", controllers.DefaultBackupStatusTimeout, "Timeout for a single backup-restore leader election poll.")
+	flag.DurationVar(&backupRequestTimeout, "backup-request-timeout", etcdbackup.DefaultBackupRequestTimeout, "Timeout for a single request to the backup-restore sidecar issued by the etcd backup controller.")
+	flag.DurationVar(&crashLoopRemediationCooldown, "crash-loop-remediation-cooldown", controllers.DefaultCrashLoopRemediationCooldown, "Minimum time between two remediating deletes of the same crashlooping etcd pod.")
+	flag.IntVar(&maxConcurrentCrashLoopDeletes, "max-concurrent-crash-loop-deletes", controllers.DefaultMaxConcurrentCrashLoopDeletes, "Maximum number of crashlooping pods of a single Etcd cluster remediated at once.")
 
 	flag.Parse()
 
@@ -94,19 +115,48 @@ func main() {
 		setupLog.Error(err, "Unable to initialize controller with image vector")
 		os.Exit(1)
 	}
+	etcd.CrashLoopRemediationCooldown = crashLoopRemediationCooldown
+	etcd.MaxConcurrentCrashLoopDeletes = maxConcurrentCrashLoopDeletes
 
 	if err := etcd.SetupWithManager(mgr, etcdWorkers, ignoreOperationAnnotation); err != nil {
 		setupLog.Error(err, "Unable to create controller", "Controller", "Etcd")
 		os.Exit(1)
 	}
 
-	custodian := controllers.NewEtcdCustodian(mgr)
+	custodian := controllers.NewEtcdCustodianWithConfig(mgr, etcdConnectionTimeout, etcdStatusSyncPeriod, backupStatusPollInterval, backupStatusTimeout)
 
 	if err := custodian.SetupWithManager(ctx, mgr, custodianWorkers); err != nil {
 		setupLog.Error(err, "Unable to create controller", "Controller", "Etcd Custodian")
 		os.Exit(1)
 	}
 
+	etcdBackup := etcdbackup.NewReconcilerWithConfig(mgr, backupRequestTimeout)
+	if err := etcdBackup.SetupWithManager(mgr, etcdBackupWorkers); err != nil {
+		setupLog.Error(err, "Unable to create controller", "Controller", "Etcd Backup")
+		os.Exit(1)
+	}
+
+	if err := bundle.NewServiceReconciler(mgr).SetupWithManager(mgr, bundleWorkers); err != nil {
+		setupLog.Error(err, "Unable to create controller", "Controller", "Bundle Service")
+		os.Exit(1)
+	}
+	if err := bundle.NewConfigMapReconciler(mgr).SetupWithManager(mgr, bundleWorkers); err != nil {
+		setupLog.Error(err, "Unable to create controller", "Controller", "Bundle ConfigMap")
+		os.Exit(1)
+	}
+	if err := bundle.NewStatefulSetReconciler(mgr).SetupWithManager(mgr, bundleWorkers); err != nil {
+		setupLog.Error(err, "Unable to create controller", "Controller", "Bundle StatefulSet")
+		os.Exit(1)
+	}
+	if err := bundle.NewPodReconciler(mgr).SetupWithManager(mgr, bundleWorkers); err != nil {
+		setupLog.Error(err, "Unable to create controller", "Controller", "Bundle Pod")
+		os.Exit(1)
+	}
+	if err := bundle.NewPVCReconciler(mgr).SetupWithManager(mgr, bundleWorkers); err != nil {
+		setupLog.Error(err, "Unable to create controller", "Controller", "Bundle PersistentVolumeClaim")
+		os.Exit(1)
+	}
+
 	// +kubebuilder:scaffold:builder
 
 	setupLog.Info("Starting manager")
@@ -0,0 +1,221 @@
+// Copyright (c) 2021 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package bundle reconciles the read-only EtcdResourceBundleState, which aggregates a compact
+// status summary of the Kubernetes objects druid manages for a single Etcd cluster. One
+// controller per watched object kind (Service, ConfigMap, StatefulSet, Pod, PersistentVolumeClaim)
+// maintains its own slice of the bundle's status via server-side apply, so concurrent writers
+// never clobber each other's fields. These controllers are strictly observational: a failure to
+// find an owning Etcd or to patch a bundle is logged and swallowed rather than returned, since the
+// bundle is a convenience view, not something the cluster's health depends on, and it runs as its
+// own independent controller so such failures can never hold up EtcdReconciler's own reconcile loop.
+package bundle
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	druidv1alpha1 "github.com/gardener/etcd-druid/api/v1alpha1"
+
+	"github.com/gardener/etcd-druid/pkg/common"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// etcdGVK identifies the Etcd kind for OwnerReference and annotation based ownership checks,
+// duplicated from controllers.etcdGVK since pkg/ must not import the top-level controllers package.
+var etcdGVK = druidv1alpha1.GroupVersion.WithKind("Etcd")
+
+// fieldOwner is the prefix of the server-side apply field managers used by the bundle
+// sub-controllers. applyBundleStatus suffixes it with the status slice being applied, so each
+// controller applies under its own field manager and never drops another controller's slice.
+const fieldOwner = "etcd-druid-bundle-controller"
+
+// findOwningEtcd resolves the Etcd that obj belongs to, or returns a nil Etcd if none can be
+// determined. Objects druid sets a controller reference on directly (Service, ConfigMap,
+// StatefulSet) are resolved via that OwnerReference or, failing that, via druid's Gardener-owned
+// annotations; objects druid does not own directly (Pod, PersistentVolumeClaim, which are owned by
+// the StatefulSet instead) are resolved by matching every Etcd's label selector in the namespace.
+func findOwningEtcd(ctx context.Context, c client.Client, obj client.Object) (*druidv1alpha1.Etcd, error) {
+	for _, ref := range obj.GetOwnerReferences() {
+		if ref.Kind != etcdGVK.Kind {
+			continue
+		}
+		etcd := &druidv1alpha1.Etcd{}
+		if err := c.Get(ctx, types.NamespacedName{Namespace: obj.GetNamespace(), Name: ref.Name}, etcd); err != nil {
+			if apierrors.IsNotFound(err) {
+				break
+			}
+			return nil, err
+		}
+		if etcd.UID == ref.UID {
+			return etcd, nil
+		}
+	}
+
+	if etcd, err := findOwningEtcdByAnnotations(ctx, c, obj); etcd != nil || err != nil {
+		return etcd, err
+	}
+
+	return findOwningEtcdBySelector(ctx, c, obj)
+}
+
+// findOwningEtcdByAnnotations mirrors the repo's existing checkEtcdAnnotations convention: an
+// object adopted by druid without a direct OwnerReference still carries the namespace/name of its
+// owning Etcd in the Gardener-owned-by annotations.
+func findOwningEtcdByAnnotations(ctx context.Context, c client.Client, obj client.Object) (*druidv1alpha1.Etcd, error) {
+	annotations := obj.GetAnnotations()
+	if annotations == nil {
+		return nil, nil
+	}
+	ownedBy, ok := annotations[common.GardenerOwnedBy]
+	if !ok {
+		return nil, nil
+	}
+	if ownerType, ok := annotations[common.GardenerOwnerType]; !ok || ownerType != strings.ToLower(etcdGVK.Kind) {
+		return nil, nil
+	}
+	namespace, name, found := strings.Cut(ownedBy, "/")
+	if !found {
+		return nil, nil
+	}
+	etcd := &druidv1alpha1.Etcd{}
+	if err := c.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, etcd); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return etcd, nil
+}
+
+// findOwningEtcdBySelector lists the Etcds in obj's namespace and returns the one whose pod label
+// selector matches obj's labels, the same selector druid already uses to list an Etcd's Pods and
+// PersistentVolumeClaims elsewhere.
+func findOwningEtcdBySelector(ctx context.Context, c client.Client, obj client.Object) (*druidv1alpha1.Etcd, error) {
+	etcds := &druidv1alpha1.EtcdList{}
+	if err := c.List(ctx, etcds, client.InNamespace(obj.GetNamespace())); err != nil {
+		return nil, err
+	}
+	for i := range etcds.Items {
+		etcd := &etcds.Items[i]
+		if etcd.Spec.Selector == nil {
+			continue
+		}
+		selector, err := metav1.LabelSelectorAsSelector(etcd.Spec.Selector)
+		if err != nil {
+			continue
+		}
+		if selector.Matches(labels.Set(obj.GetLabels())) {
+			return etcd, nil
+		}
+	}
+	return nil, nil
+}
+
+// +kubebuilder:rbac:groups=druid.gardener.cloud,resources=etcdresourcebundlestates,verbs=get;list;watch;create
+// +kubebuilder:rbac:groups=druid.gardener.cloud,resources=etcds,verbs=get;list;watch
+
+// ensureBundle gets the EtcdResourceBundleState for etcd, creating it if it does not yet exist.
+func ensureBundle(ctx context.Context, c client.Client, scheme *runtime.Scheme, etcd *druidv1alpha1.Etcd) (*druidv1alpha1.EtcdResourceBundleState, error) {
+	bundle := &druidv1alpha1.EtcdResourceBundleState{}
+	err := c.Get(ctx, types.NamespacedName{Namespace: etcd.Namespace, Name: etcd.Name}, bundle)
+	if err == nil {
+		return bundle, nil
+	}
+	if !apierrors.IsNotFound(err) {
+		return nil, err
+	}
+
+	bundle = &druidv1alpha1.EtcdResourceBundleState{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      etcd.Name,
+			Namespace: etcd.Namespace,
+		},
+		Spec: druidv1alpha1.EtcdResourceBundleStateSpec{
+			EtcdRef: druidv1alpha1.CrossVersionObjectReference{
+				Kind:       etcdGVK.Kind,
+				Name:       etcd.Name,
+				APIVersion: druidv1alpha1.GroupVersion.String(),
+			},
+		},
+	}
+	if err := controllerutil.SetControllerReference(etcd, bundle, scheme); err != nil {
+		return nil, err
+	}
+	if err := c.Create(ctx, bundle); err != nil {
+		if apierrors.IsAlreadyExists(err) {
+			return bundle, c.Get(ctx, types.NamespacedName{Namespace: etcd.Namespace, Name: etcd.Name}, bundle)
+		}
+		return nil, err
+	}
+	return bundle, nil
+}
+
+// applyBundleStatus server-side-applies status onto the EtcdResourceBundleState named after etcd,
+// with only the field(s) the caller populated in status considered owned by this field manager.
+func applyBundleStatus(ctx context.Context, c client.Client, etcd *druidv1alpha1.Etcd, status druidv1alpha1.EtcdResourceBundleStateStatus) error {
+	apply := &druidv1alpha1.EtcdResourceBundleState{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: etcd.Namespace,
+			Name:      etcd.Name,
+		},
+		Status: status,
+	}
+	apply.SetGroupVersionKind(druidv1alpha1.GroupVersion.WithKind("EtcdResourceBundleState"))
+	// Server-side apply removes fields a manager applied before but omits now, so every slice is
+	// applied under a field manager of its own.
+	owner := fieldOwner
+	switch {
+	case status.Services != nil:
+		owner += "-services"
+	case status.ConfigMaps != nil:
+		owner += "-configmaps"
+	case status.StatefulSets != nil:
+		owner += "-statefulsets"
+	case status.Pods != nil:
+		owner += "-pods"
+	case status.PersistentVolumeClaims != nil:
+		owner += "-pvcs"
+	}
+	return c.Status().Patch(ctx, apply, client.Apply, client.FieldOwner(owner), client.ForceOwnership)
+}
+
+// conditionFrom maps any condition shape sharing Type/Status/Reason/Message/LastTransitionTime
+// fields into druid's shared Condition type. It is intentionally called with already-extracted
+// fields, since Pod, PersistentVolumeClaim and StatefulSet conditions are all distinct Go types
+// with no common interface.
+func conditionFrom(conditionType, status, reason, message string, lastTransitionTime metav1.Time) druidv1alpha1.Condition {
+	now := metav1.Now()
+	return druidv1alpha1.Condition{
+		Type:               druidv1alpha1.ConditionType(conditionType),
+		Status:             druidv1alpha1.ConditionStatus(status),
+		Reason:             reason,
+		Message:            message,
+		LastTransitionTime: lastTransitionTime,
+		LastUpdateTime:     now,
+	}
+}
+
+// bundleLogName formats a namespace/name pair the same way the rest of the repo does for log
+// values.
+func bundleLogName(namespace, name string) string {
+	return fmt.Sprintf("%s/%s", namespace, name)
+}
@@ -0,0 +1,128 @@
+// Copyright (c) 2021 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bundle
+
+import (
+	"context"
+
+	druidv1alpha1 "github.com/gardener/etcd-druid/api/v1alpha1"
+
+	kutil "github.com/gardener/gardener/pkg/utils/kubernetes"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+// PodReconciler maintains the Pods slice of the EtcdResourceBundleState belonging to the Etcd that
+// owns a watched Pod.
+type PodReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+	logger logr.Logger
+}
+
+// NewPodReconciler creates a new bundle PodReconciler.
+func NewPodReconciler(mgr manager.Manager) *PodReconciler {
+	return &PodReconciler{
+		Client: mgr.GetClient(),
+		Scheme: mgr.GetScheme(),
+		logger: log.Log.WithName("bundle-pod-controller"),
+	}
+}
+
+// +kubebuilder:rbac:groups=druid.gardener.cloud,resources=etcdresourcebundlestates/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=core,resources=pods,verbs=get;list;watch
+
+// Reconcile refreshes the owning Etcd's bundle Pods status to reflect every Pod currently matching
+// that Etcd's selector. It never returns an error for conditions that merely prevent the bundle
+// from being updated, since the bundle is an observational convenience view only.
+func (r *PodReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := r.logger.WithValues("pod", kutil.Key(req.Namespace, req.Name).String())
+
+	pod := &corev1.Pod{}
+	if err := r.Get(ctx, req.NamespacedName, pod); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	etcd, err := findOwningEtcd(ctx, r.Client, pod)
+	if err != nil {
+		logger.Error(err, "Unable to determine owning Etcd")
+		return ctrl.Result{}, nil
+	}
+	if etcd == nil {
+		return ctrl.Result{}, nil
+	}
+
+	if _, err := ensureBundle(ctx, r.Client, r.Scheme, etcd); err != nil {
+		logger.Error(err, "Unable to ensure bundle exists", "etcd", kutil.Key(etcd.Namespace, etcd.Name).String())
+		return ctrl.Result{}, nil
+	}
+
+	selector, err := metav1.LabelSelectorAsSelector(etcd.Spec.Selector)
+	if err != nil {
+		logger.Error(err, "Unable to build selector for Etcd", "etcd", kutil.Key(etcd.Namespace, etcd.Name).String())
+		return ctrl.Result{}, nil
+	}
+	pods := &corev1.PodList{}
+	if err := r.List(ctx, pods, client.InNamespace(etcd.Namespace), client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		logger.Error(err, "Unable to list Pods for Etcd", "etcd", kutil.Key(etcd.Namespace, etcd.Name).String())
+		return ctrl.Result{}, nil
+	}
+
+	statuses := make([]druidv1alpha1.PodStatus, 0, len(pods.Items))
+	for _, p := range pods.Items {
+		ready := false
+		conditions := make([]druidv1alpha1.Condition, 0, len(p.Status.Conditions))
+		for _, condition := range p.Status.Conditions {
+			conditions = append(conditions, conditionFrom(string(condition.Type), string(condition.Status), condition.Reason, condition.Message, condition.LastTransitionTime))
+			if condition.Type == corev1.PodReady && condition.Status == corev1.ConditionTrue {
+				ready = true
+			}
+		}
+		var restartCount int32
+		for _, containerStatus := range p.Status.ContainerStatuses {
+			if containerStatus.RestartCount > restartCount {
+				restartCount = containerStatus.RestartCount
+			}
+		}
+		statuses = append(statuses, druidv1alpha1.PodStatus{
+			Name:         p.Name,
+			Phase:        string(p.Status.Phase),
+			Ready:        ready,
+			RestartCount: restartCount,
+			Conditions:   conditions,
+		})
+	}
+
+	if err := applyBundleStatus(ctx, r.Client, etcd, druidv1alpha1.EtcdResourceBundleStateStatus{Pods: statuses}); err != nil && !apierrors.IsNotFound(err) {
+		logger.Error(err, "Unable to patch bundle Pods status", "etcd", kutil.Key(etcd.Namespace, etcd.Name).String())
+	}
+	return ctrl.Result{}, nil
+}
+
+// SetupWithManager sets up the bundle PodReconciler with the given manager.
+func (r *PodReconciler) SetupWithManager(mgr ctrl.Manager, workers int) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		WithOptions(controller.Options{MaxConcurrentReconciles: workers}).
+		For(&corev1.Pod{}).
+		Complete(r)
+}
@@ -0,0 +1,117 @@
+// Copyright (c) 2021 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bundle
+
+import (
+	"context"
+
+	druidv1alpha1 "github.com/gardener/etcd-druid/api/v1alpha1"
+
+	kutil "github.com/gardener/gardener/pkg/utils/kubernetes"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+// PVCReconciler maintains the PersistentVolumeClaims slice of the EtcdResourceBundleState
+// belonging to the Etcd that owns a watched PersistentVolumeClaim.
+type PVCReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+	logger logr.Logger
+}
+
+// NewPVCReconciler creates a new bundle PVCReconciler.
+func NewPVCReconciler(mgr manager.Manager) *PVCReconciler {
+	return &PVCReconciler{
+		Client: mgr.GetClient(),
+		Scheme: mgr.GetScheme(),
+		logger: log.Log.WithName("bundle-pvc-controller"),
+	}
+}
+
+// +kubebuilder:rbac:groups=druid.gardener.cloud,resources=etcdresourcebundlestates/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=core,resources=persistentvolumeclaims,verbs=get;list;watch
+
+// Reconcile refreshes the owning Etcd's bundle PersistentVolumeClaims status to reflect every
+// PersistentVolumeClaim currently matching that Etcd's selector. It never returns an error for
+// conditions that merely prevent the bundle from being updated, since the bundle is an
+// observational convenience view only.
+func (r *PVCReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := r.logger.WithValues("persistentvolumeclaim", kutil.Key(req.Namespace, req.Name).String())
+
+	pvc := &corev1.PersistentVolumeClaim{}
+	if err := r.Get(ctx, req.NamespacedName, pvc); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	etcd, err := findOwningEtcd(ctx, r.Client, pvc)
+	if err != nil {
+		logger.Error(err, "Unable to determine owning Etcd")
+		return ctrl.Result{}, nil
+	}
+	if etcd == nil {
+		return ctrl.Result{}, nil
+	}
+
+	if _, err := ensureBundle(ctx, r.Client, r.Scheme, etcd); err != nil {
+		logger.Error(err, "Unable to ensure bundle exists", "etcd", kutil.Key(etcd.Namespace, etcd.Name).String())
+		return ctrl.Result{}, nil
+	}
+
+	selector, err := metav1.LabelSelectorAsSelector(etcd.Spec.Selector)
+	if err != nil {
+		logger.Error(err, "Unable to build selector for Etcd", "etcd", kutil.Key(etcd.Namespace, etcd.Name).String())
+		return ctrl.Result{}, nil
+	}
+	pvcs := &corev1.PersistentVolumeClaimList{}
+	if err := r.List(ctx, pvcs, client.InNamespace(etcd.Namespace), client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		logger.Error(err, "Unable to list PersistentVolumeClaims for Etcd", "etcd", kutil.Key(etcd.Namespace, etcd.Name).String())
+		return ctrl.Result{}, nil
+	}
+
+	statuses := make([]druidv1alpha1.PVCStatus, 0, len(pvcs.Items))
+	for _, p := range pvcs.Items {
+		conditions := make([]druidv1alpha1.Condition, 0, len(p.Status.Conditions))
+		for _, condition := range p.Status.Conditions {
+			conditions = append(conditions, conditionFrom(string(condition.Type), string(condition.Status), condition.Reason, condition.Message, condition.LastTransitionTime))
+		}
+		statuses = append(statuses, druidv1alpha1.PVCStatus{
+			Name:       p.Name,
+			Phase:      string(p.Status.Phase),
+			Conditions: conditions,
+		})
+	}
+
+	if err := applyBundleStatus(ctx, r.Client, etcd, druidv1alpha1.EtcdResourceBundleStateStatus{PersistentVolumeClaims: statuses}); err != nil && !apierrors.IsNotFound(err) {
+		logger.Error(err, "Unable to patch bundle PersistentVolumeClaims status", "etcd", kutil.Key(etcd.Namespace, etcd.Name).String())
+	}
+	return ctrl.Result{}, nil
+}
+
+// SetupWithManager sets up the bundle PVCReconciler with the given manager.
+func (r *PVCReconciler) SetupWithManager(mgr ctrl.Manager, workers int) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		WithOptions(controller.Options{MaxConcurrentReconciles: workers}).
+		For(&corev1.PersistentVolumeClaim{}).
+		Complete(r)
+}
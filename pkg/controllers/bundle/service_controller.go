@@ -0,0 +1,111 @@
+// Copyright (c) 2021 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bundle
+
+import (
+	"context"
+
+	druidv1alpha1 "github.com/gardener/etcd-druid/api/v1alpha1"
+
+	kutil "github.com/gardener/gardener/pkg/utils/kubernetes"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+// ServiceReconciler maintains the Services slice of the EtcdResourceBundleState belonging to the
+// Etcd that owns a watched Service.
+type ServiceReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+	logger logr.Logger
+}
+
+// NewServiceReconciler creates a new bundle ServiceReconciler.
+func NewServiceReconciler(mgr manager.Manager) *ServiceReconciler {
+	return &ServiceReconciler{
+		Client: mgr.GetClient(),
+		Scheme: mgr.GetScheme(),
+		logger: log.Log.WithName("bundle-service-controller"),
+	}
+}
+
+// +kubebuilder:rbac:groups=druid.gardener.cloud,resources=etcdresourcebundlestates/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=core,resources=services,verbs=get;list;watch
+
+// Reconcile refreshes the owning Etcd's bundle Services status to reflect every Service currently
+// matching that Etcd's selector. It never returns an error for conditions that merely prevent the
+// bundle from being updated, since the bundle is an observational convenience view only.
+func (r *ServiceReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := r.logger.WithValues("service", kutil.Key(req.Namespace, req.Name).String())
+
+	svc := &corev1.Service{}
+	if err := r.Get(ctx, req.NamespacedName, svc); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	etcd, err := findOwningEtcd(ctx, r.Client, svc)
+	if err != nil {
+		logger.Error(err, "Unable to determine owning Etcd")
+		return ctrl.Result{}, nil
+	}
+	if etcd == nil {
+		return ctrl.Result{}, nil
+	}
+
+	if _, err := ensureBundle(ctx, r.Client, r.Scheme, etcd); err != nil {
+		logger.Error(err, "Unable to ensure bundle exists", "etcd", kutil.Key(etcd.Namespace, etcd.Name).String())
+		return ctrl.Result{}, nil
+	}
+
+	selector, err := metav1.LabelSelectorAsSelector(etcd.Spec.Selector)
+	if err != nil {
+		logger.Error(err, "Unable to build selector for Etcd", "etcd", kutil.Key(etcd.Namespace, etcd.Name).String())
+		return ctrl.Result{}, nil
+	}
+	services := &corev1.ServiceList{}
+	if err := r.List(ctx, services, client.InNamespace(etcd.Namespace), client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		logger.Error(err, "Unable to list Services for Etcd", "etcd", kutil.Key(etcd.Namespace, etcd.Name).String())
+		return ctrl.Result{}, nil
+	}
+
+	statuses := make([]druidv1alpha1.ServiceStatus, 0, len(services.Items))
+	for _, svc := range services.Items {
+		statuses = append(statuses, druidv1alpha1.ServiceStatus{
+			Name:      svc.Name,
+			ClusterIP: svc.Spec.ClusterIP,
+		})
+	}
+
+	if err := applyBundleStatus(ctx, r.Client, etcd, druidv1alpha1.EtcdResourceBundleStateStatus{Services: statuses}); err != nil && !apierrors.IsNotFound(err) {
+		logger.Error(err, "Unable to patch bundle Services status", "etcd", kutil.Key(etcd.Namespace, etcd.Name).String())
+	}
+	return ctrl.Result{}, nil
+}
+
+// SetupWithManager sets up the bundle ServiceReconciler with the given manager.
+func (r *ServiceReconciler) SetupWithManager(mgr ctrl.Manager, workers int) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		WithOptions(controller.Options{MaxConcurrentReconciles: workers}).
+		For(&corev1.Service{}).
+		Complete(r)
+}
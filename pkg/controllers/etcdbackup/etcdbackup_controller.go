@@ -0,0 +1,296 @@
+// Copyright (c) 2021 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package etcdbackup reconciles the EtcdBackup resource, which layers a scheduled snapshot and
+// retention policy on top of an existing Etcd cluster.
+package etcdbackup
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	druidv1alpha1 "github.com/gardener/etcd-druid/api/v1alpha1"
+
+	kutil "github.com/gardener/gardener/pkg/utils/kubernetes"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+// DefaultMaxBackups is the retention count used when EtcdBackupPolicy.MaxBackups is unset.
+const DefaultMaxBackups = 7
+
+// DefaultBackupRequestTimeout bounds a single HTTP call to the backup-restore sidecar.
+const DefaultBackupRequestTimeout = 30 * time.Second
+
+// defaultBackupRestorePort is used when Etcd.Spec.Backup.Port is not set.
+const defaultBackupRestorePort = 8080
+
+// Reconciler reconciles an EtcdBackup object.
+type Reconciler struct {
+	client.Client
+	Scheme         *runtime.Scheme
+	logger         logr.Logger
+	requestTimeout time.Duration
+}
+
+// NewReconciler creates a new EtcdBackup Reconciler.
+func NewReconciler(mgr manager.Manager) *Reconciler {
+	return NewReconcilerWithConfig(mgr, DefaultBackupRequestTimeout)
+}
+
+// NewReconcilerWithConfig creates a new EtcdBackup Reconciler with the given backup-restore
+// request timeout.
+func NewReconcilerWithConfig(mgr manager.Manager, requestTimeout time.Duration) *Reconciler {
+	return &Reconciler{
+		Client:         mgr.GetClient(),
+		Scheme:         mgr.GetScheme(),
+		logger:         log.Log.WithName("etcdbackup-controller"),
+		requestTimeout: requestTimeout,
+	}
+}
+
+// +kubebuilder:rbac:groups=druid.gardener.cloud,resources=etcdbackups,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=druid.gardener.cloud,resources=etcdbackups/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=druid.gardener.cloud,resources=etcds,verbs=get;list;watch
+
+// Reconcile triggers a snapshot of the referenced Etcd cluster once its schedule is due, and
+// prunes the object store down to the configured retention count.
+func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := r.logger.WithValues("etcdbackup", kutil.Key(req.Namespace, req.Name).String())
+
+	backup := &druidv1alpha1.EtcdBackup{}
+	if err := r.Get(ctx, req.NamespacedName, backup); err != nil {
+		if apierrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	interval := time.Duration(backup.Spec.BackupPolicy.BackupIntervalInSeconds) * time.Second
+	if backup.Status.LastSuccessTime != nil {
+		nextRun := backup.Status.LastSuccessTime.Add(interval)
+		if delta := time.Until(nextRun); delta > 0 {
+			logger.Info("Next scheduled snapshot is not due yet, requeuing", "nextRun", nextRun)
+			return ctrl.Result{RequeueAfter: delta}, nil
+		}
+	}
+
+	etcd := &druidv1alpha1.Etcd{}
+	if err := r.Get(ctx, types.NamespacedName{Namespace: req.Namespace, Name: backup.Spec.EtcdRef.Name}, etcd); err != nil {
+		return ctrl.Result{}, r.recordFailure(ctx, backup, fmt.Errorf("unable to get referenced Etcd %q: %w", backup.Spec.EtcdRef.Name, err))
+	}
+
+	if err := r.triggerSnapshot(ctx, etcd, backup.Spec.BackupPolicy); err != nil {
+		logger.Error(err, "Unable to trigger snapshot")
+		return ctrl.Result{}, r.recordFailure(ctx, backup, err)
+	}
+
+	backups, err := r.listSnapshots(ctx, etcd)
+	if err != nil {
+		logger.Error(err, "Unable to list snapshots")
+		return ctrl.Result{}, r.recordFailure(ctx, backup, err)
+	}
+
+	maxBackups := int32(DefaultMaxBackups)
+	if backup.Spec.BackupPolicy.MaxBackups != nil {
+		maxBackups = *backup.Spec.BackupPolicy.MaxBackups
+	}
+	backups, err = r.pruneSnapshots(ctx, etcd, backups, maxBackups)
+	if err != nil {
+		logger.Error(err, "Unable to prune snapshots")
+		return ctrl.Result{}, r.recordFailure(ctx, backup, err)
+	}
+
+	return ctrl.Result{RequeueAfter: interval}, r.recordSuccess(ctx, backup, backups)
+}
+
+// triggerSnapshot asks the backup-restore sidecar serving etcd to take a full or delta snapshot,
+// depending on policy.FullSnapshot.
+func (r *Reconciler) triggerSnapshot(ctx context.Context, etcd *druidv1alpha1.Etcd, policy druidv1alpha1.EtcdBackupPolicy) error {
+	kind := "full"
+	if policy.FullSnapshot != nil && !*policy.FullSnapshot {
+		kind = "delta"
+	}
+	return r.doRequest(ctx, etcd, http.MethodPost, fmt.Sprintf("/snapshot/%s", kind), nil)
+}
+
+// listSnapshots lists the snapshot objects currently held in the object store backing etcd.
+func (r *Reconciler) listSnapshots(ctx context.Context, etcd *druidv1alpha1.Etcd) ([]druidv1alpha1.BackupFileInfo, error) {
+	var listed []druidv1alpha1.BackupFileInfo
+	if err := r.doRequest(ctx, etcd, http.MethodGet, "/snapshot/list", &listed); err != nil {
+		return nil, err
+	}
+	sort.Slice(listed, func(i, j int) bool { return listed[i].CreationTime.Before(&listed[j].CreationTime) })
+	return listed, nil
+}
+
+// pruneSnapshots deletes the oldest snapshots via the backup-restore sidecar until at most
+// maxBackups remain, and returns the resulting, still oldest-first, list.
+func (r *Reconciler) pruneSnapshots(ctx context.Context, etcd *druidv1alpha1.Etcd, backups []druidv1alpha1.BackupFileInfo, maxBackups int32) ([]druidv1alpha1.BackupFileInfo, error) {
+	for len(backups) > int(maxBackups) {
+		oldest := backups[0]
+		if err := r.doRequest(ctx, etcd, http.MethodDelete, fmt.Sprintf("/snapshot/%s", oldest.Name), nil); err != nil {
+			return nil, fmt.Errorf("unable to delete snapshot %q: %w", oldest.Name, err)
+		}
+		backups = backups[1:]
+	}
+	return backups, nil
+}
+
+// doRequest issues an HTTP request against the backup-restore sidecar fronted by etcd's client
+// Service, optionally decoding a JSON response body into out.
+func (r *Reconciler) doRequest(ctx context.Context, etcd *druidv1alpha1.Etcd, method, path string, out interface{}) error {
+	port := int32(defaultBackupRestorePort)
+	if etcd.Spec.Backup.Port != nil {
+		port = *etcd.Spec.Backup.Port
+	}
+	scheme := "http"
+	httpClient := &http.Client{Timeout: r.requestTimeout}
+	if etcd.Spec.Backup.TLS != nil {
+		tlsConfig, err := r.tlsConfigFor(ctx, etcd)
+		if err != nil {
+			return err
+		}
+		scheme = "https"
+		httpClient.Transport = &http.Transport{TLSClientConfig: tlsConfig}
+	}
+
+	serviceName := fmt.Sprintf("%s-client", etcd.Name)
+	if etcd.Status.ServiceName != nil {
+		serviceName = *etcd.Status.ServiceName
+	}
+	url := fmt.Sprintf("%s://%s.%s:%d%s", scheme, serviceName, etcd.Namespace, port, path)
+
+	ctx, cancel := context.WithTimeout(ctx, r.requestTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, method, url, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("backup-restore request %s %s failed: %w", method, path, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("backup-restore request %s %s returned status %d", method, path, resp.StatusCode)
+	}
+	if out == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+func (r *Reconciler) tlsConfigFor(ctx context.Context, etcd *druidv1alpha1.Etcd) (*tls.Config, error) {
+	tlsSpec := etcd.Spec.Backup.TLS
+
+	caSecret := &corev1.Secret{}
+	if err := r.Get(ctx, types.NamespacedName{Name: tlsSpec.TLSCASecretRef.Name, Namespace: etcd.Namespace}, caSecret); err != nil {
+		return nil, fmt.Errorf("unable to fetch backup-restore CA secret: %w", err)
+	}
+
+	caPool := x509.NewCertPool()
+	if !caPool.AppendCertsFromPEM(caSecret.Data[corev1.ServiceAccountRootCAKey]) {
+		return nil, fmt.Errorf("unable to parse CA certificate from secret %s/%s", caSecret.Namespace, caSecret.Name)
+	}
+
+	return &tls.Config{RootCAs: caPool}, nil
+}
+
+// recordSuccess records a completed snapshot/prune cycle in backup.Status.
+func (r *Reconciler) recordSuccess(ctx context.Context, backup *druidv1alpha1.EtcdBackup, backups []druidv1alpha1.BackupFileInfo) error {
+	return kutil.TryUpdateStatus(ctx, retry.DefaultBackoff, r.Client, backup, func() error {
+		now := metav1.Now()
+		backup.Status.LastSuccessTime = &now
+		backup.Status.Backups = backups
+		backup.Status.ObservedGeneration = &backup.Generation
+		backup.Status.Conditions = mergeConditions(backup.Status.Conditions, druidv1alpha1.Condition{
+			Type:    druidv1alpha1.ConditionTypeBackupFailed,
+			Status:  druidv1alpha1.ConditionFalse,
+			Reason:  "SnapshotSucceeded",
+			Message: "The most recent scheduled snapshot completed successfully.",
+		})
+		return nil
+	})
+}
+
+// recordFailure records a BackupFailed condition and returns snapshotErr so the controller
+// requeues with the workqueue's exponential backoff.
+func (r *Reconciler) recordFailure(ctx context.Context, backup *druidv1alpha1.EtcdBackup, snapshotErr error) error {
+	if err := kutil.TryUpdateStatus(ctx, retry.DefaultBackoff, r.Client, backup, func() error {
+		backup.Status.Conditions = mergeConditions(backup.Status.Conditions, druidv1alpha1.Condition{
+			Type:    druidv1alpha1.ConditionTypeBackupFailed,
+			Status:  druidv1alpha1.ConditionTrue,
+			Reason:  "SnapshotFailed",
+			Message: snapshotErr.Error(),
+		})
+		return nil
+	}); err != nil {
+		return err
+	}
+	return snapshotErr
+}
+
+// mergeConditions merges updates into existing by Type, stamping LastUpdateTime and preserving
+// LastTransitionTime when the Status of a condition is unchanged.
+func mergeConditions(existing []druidv1alpha1.Condition, updates ...druidv1alpha1.Condition) []druidv1alpha1.Condition {
+	now := metav1.Now()
+	for _, update := range updates {
+		found := false
+		for i, cond := range existing {
+			if cond.Type != update.Type {
+				continue
+			}
+			found = true
+			update.LastTransitionTime = cond.LastTransitionTime
+			if cond.Status != update.Status {
+				update.LastTransitionTime = now
+			}
+			update.LastUpdateTime = now
+			existing[i] = update
+			break
+		}
+		if !found {
+			update.LastTransitionTime = now
+			update.LastUpdateTime = now
+			existing = append(existing, update)
+		}
+	}
+	return existing
+}
+
+// SetupWithManager sets up the controller with mgr.
+func (r *Reconciler) SetupWithManager(mgr ctrl.Manager, workers int) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		WithOptions(controller.Options{MaxConcurrentReconciles: workers}).
+		For(&druidv1alpha1.EtcdBackup{}).
+		Complete(r)
+}
@@ -0,0 +1,294 @@
+// Copyright (c) 2021 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package etcdclient provides a thin wrapper around the etcd client/v3 API that druid uses
+// to probe the actual health of an etcd cluster, rather than relying solely on Kubernetes
+// StatefulSet/Pod status.
+package etcdclient
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	etcdserverpb "go.etcd.io/etcd/api/v3/etcdserverpb"
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+// ConnectionError wraps an error encountered while dialing or querying the etcd cluster, as
+// opposed to an error in how the cluster itself is behaving (e.g. no quorum). Callers use
+// IsConnectionError to distinguish the two so they can requeue with backoff instead of
+// reporting a hard failure.
+type ConnectionError struct {
+	err error
+}
+
+func (e *ConnectionError) Error() string { return e.err.Error() }
+func (e *ConnectionError) Unwrap() error { return e.err }
+
+// IsConnectionError returns true if err (or any error it wraps) is a ConnectionError.
+func IsConnectionError(err error) bool {
+	var connErr *ConnectionError
+	return errors.As(err, &connErr)
+}
+
+// Member describes a single etcd cluster member as reported by the etcd client API.
+type Member struct {
+	// ID is the hex-encoded etcd member ID.
+	ID string
+	// Name is the member's advertised name.
+	Name string
+	// PeerURLs are the peer URLs this member advertises.
+	PeerURLs []string
+	// ClientURLs are the client URLs this member advertises.
+	ClientURLs []string
+	// IsLearner indicates whether this member is a non-voting learner.
+	IsLearner bool
+}
+
+// EndpointStatus describes the result of calling Status against a single endpoint.
+type EndpointStatus struct {
+	// Endpoint is the client URL that was probed.
+	Endpoint string
+	// MemberID is the hex-encoded ID of the member serving this endpoint.
+	MemberID string
+	// Leader is the hex-encoded ID of the member this endpoint considers the Raft leader.
+	Leader string
+	// DBSize is the size in bytes of the backend database.
+	DBSize int64
+	// Revision is the current revision of the member's key-value store.
+	Revision int64
+	// RaftIndex is the current Raft applied index reported by the endpoint.
+	RaftIndex uint64
+	// IsLearner indicates whether the endpoint itself is a learner.
+	IsLearner bool
+}
+
+// AlarmType identifies the kind of alarm raised by an etcd cluster member.
+type AlarmType string
+
+const (
+	// AlarmNoSpace is raised by a member once its backend database has reached its quota.
+	AlarmNoSpace AlarmType = "NOSPACE"
+	// AlarmCorrupt is raised by a member once it detects corruption in its backend database.
+	AlarmCorrupt AlarmType = "CORRUPT"
+)
+
+// Alarm describes a single active alarm as reported by AlarmList.
+type Alarm struct {
+	// MemberID is the hex-encoded ID of the member the alarm was raised on.
+	MemberID string
+	// Type is the kind of alarm raised.
+	Type AlarmType
+}
+
+// Client is the subset of etcd client/v3 functionality druid needs to assess cluster health.
+type Client interface {
+	// MemberList lists all members currently known to the cluster.
+	MemberList(ctx context.Context) ([]Member, error)
+	// Status returns the status of a single endpoint.
+	Status(ctx context.Context, endpoint string) (*EndpointStatus, error)
+	// MemberAdd registers a new member with the given peer URL with the cluster, ahead of that
+	// member's pod starting. It must be called before the new pod joins as an existing member.
+	MemberAdd(ctx context.Context, peerURL string) error
+	// MemberRemove removes the member with the given hex-encoded ID from the cluster.
+	MemberRemove(ctx context.Context, id string) error
+	// MoveLeader transfers Raft leadership to the member with the given hex-encoded ID. The
+	// endpoint being called must itself be the current leader.
+	MoveLeader(ctx context.Context, transfereeID string) error
+	// AlarmList lists all alarms currently active anywhere in the cluster.
+	AlarmList(ctx context.Context) ([]Alarm, error)
+	// AlarmDisarm clears a previously raised alarm once its underlying condition has been
+	// resolved, e.g. after compacting and defragmenting in response to a NOSPACE alarm.
+	AlarmDisarm(ctx context.Context, alarm Alarm) error
+	// Compact compacts the key-value store history up to and including the given revision.
+	Compact(ctx context.Context, revision int64) error
+	// Defragment releases backend database fragmentation on a single member, addressed by its
+	// client endpoint.
+	Defragment(ctx context.Context, endpoint string) error
+	// Close releases the underlying connections.
+	Close() error
+}
+
+// Options configures the creation of a Client.
+type Options struct {
+	// Endpoints are the etcd client URLs to dial, typically one per StatefulSet pod.
+	Endpoints []string
+	// TLS is the TLS configuration to use, or nil for a plaintext connection.
+	TLS *tls.Config
+	// ConnectionTimeout bounds how long to wait for the initial connection and for keep-alive
+	// probes. Individual calls are bounded only by the context the caller passes.
+	ConnectionTimeout time.Duration
+}
+
+type client struct {
+	cli *clientv3.Client
+}
+
+// New dials the given endpoints and returns a Client. The returned Client must be Closed once
+// it is no longer needed.
+func New(opts Options) (Client, error) {
+	if len(opts.Endpoints) == 0 {
+		return nil, fmt.Errorf("etcdclient: at least one endpoint is required")
+	}
+	timeout := opts.ConnectionTimeout
+	if timeout <= 0 {
+		timeout = 5 * time.Second
+	}
+
+	cli, err := clientv3.New(clientv3.Config{
+		Endpoints:            opts.Endpoints,
+		DialTimeout:          timeout,
+		TLS:                  opts.TLS,
+		DialKeepAliveTime:    30 * time.Second,
+		DialKeepAliveTimeout: timeout,
+	})
+	if err != nil {
+		return nil, &ConnectionError{err: fmt.Errorf("unable to create etcd client: %w", err)}
+	}
+	return &client{cli: cli}, nil
+}
+
+func (c *client) MemberList(ctx context.Context) ([]Member, error) {
+	resp, err := c.cli.MemberList(ctx)
+	if err != nil {
+		return nil, &ConnectionError{err: fmt.Errorf("unable to list etcd members: %w", err)}
+	}
+
+	members := make([]Member, 0, len(resp.Members))
+	for _, m := range resp.Members {
+		members = append(members, Member{
+			ID:         fmt.Sprintf("%x", m.ID),
+			Name:       m.Name,
+			PeerURLs:   m.PeerURLs,
+			ClientURLs: m.ClientURLs,
+			IsLearner:  m.IsLearner,
+		})
+	}
+	return members, nil
+}
+
+func (c *client) Status(ctx context.Context, endpoint string) (*EndpointStatus, error) {
+	resp, err := c.cli.Status(ctx, endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch status for endpoint %q: %w", endpoint, err)
+	}
+	return &EndpointStatus{
+		Endpoint:  endpoint,
+		MemberID:  fmt.Sprintf("%x", resp.Header.MemberId),
+		Leader:    fmt.Sprintf("%x", resp.Leader),
+		DBSize:    resp.DbSize,
+		Revision:  resp.Header.Revision,
+		RaftIndex: resp.RaftIndex,
+		IsLearner: resp.IsLearner,
+	}, nil
+}
+
+func (c *client) MemberAdd(ctx context.Context, peerURL string) error {
+	if _, err := c.cli.MemberAdd(ctx, []string{peerURL}); err != nil {
+		return fmt.Errorf("unable to add etcd member with peer URL %q: %w", peerURL, err)
+	}
+	return nil
+}
+
+func (c *client) MemberRemove(ctx context.Context, id string) error {
+	memberID, err := strconv.ParseUint(id, 16, 64)
+	if err != nil {
+		return fmt.Errorf("invalid member ID %q: %w", id, err)
+	}
+	if _, err := c.cli.MemberRemove(ctx, memberID); err != nil {
+		return fmt.Errorf("unable to remove member %q: %w", id, err)
+	}
+	return nil
+}
+
+func (c *client) MoveLeader(ctx context.Context, transfereeID string) error {
+	memberID, err := strconv.ParseUint(transfereeID, 16, 64)
+	if err != nil {
+		return fmt.Errorf("invalid member ID %q: %w", transfereeID, err)
+	}
+	if _, err := c.cli.MoveLeader(ctx, memberID); err != nil {
+		return fmt.Errorf("unable to transfer leadership to member %q: %w", transfereeID, err)
+	}
+	return nil
+}
+
+func (c *client) AlarmList(ctx context.Context) ([]Alarm, error) {
+	resp, err := c.cli.AlarmList(ctx)
+	if err != nil {
+		return nil, &ConnectionError{err: fmt.Errorf("unable to list etcd alarms: %w", err)}
+	}
+
+	alarms := make([]Alarm, 0, len(resp.Alarms))
+	for _, a := range resp.Alarms {
+		alarms = append(alarms, Alarm{
+			MemberID: fmt.Sprintf("%x", a.MemberID),
+			Type:     alarmTypeFromProto(a.Alarm),
+		})
+	}
+	return alarms, nil
+}
+
+func (c *client) AlarmDisarm(ctx context.Context, alarm Alarm) error {
+	memberID, err := strconv.ParseUint(alarm.MemberID, 16, 64)
+	if err != nil {
+		return fmt.Errorf("invalid member ID %q: %w", alarm.MemberID, err)
+	}
+	member := &clientv3.AlarmMember{MemberID: memberID, Alarm: alarmTypeToProto(alarm.Type)}
+	if _, err := c.cli.AlarmDisarm(ctx, member); err != nil {
+		return fmt.Errorf("unable to disarm %s alarm on member %q: %w", alarm.Type, alarm.MemberID, err)
+	}
+	return nil
+}
+
+func (c *client) Compact(ctx context.Context, revision int64) error {
+	if _, err := c.cli.Compact(ctx, revision); err != nil {
+		return fmt.Errorf("unable to compact to revision %d: %w", revision, err)
+	}
+	return nil
+}
+
+func (c *client) Defragment(ctx context.Context, endpoint string) error {
+	if _, err := c.cli.Defragment(ctx, endpoint); err != nil {
+		return fmt.Errorf("unable to defragment endpoint %q: %w", endpoint, err)
+	}
+	return nil
+}
+
+func alarmTypeFromProto(t etcdserverpb.AlarmType) AlarmType {
+	switch t {
+	case etcdserverpb.AlarmType_CORRUPT:
+		return AlarmCorrupt
+	case etcdserverpb.AlarmType_NOSPACE:
+		return AlarmNoSpace
+	default:
+		return AlarmType(t.String())
+	}
+}
+
+func alarmTypeToProto(t AlarmType) etcdserverpb.AlarmType {
+	switch t {
+	case AlarmCorrupt:
+		return etcdserverpb.AlarmType_CORRUPT
+	default:
+		return etcdserverpb.AlarmType_NOSPACE
+	}
+}
+
+func (c *client) Close() error {
+	return c.cli.Close()
+}
@@ -0,0 +1,134 @@
+// Copyright (c) 2021 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etcdclient
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"time"
+
+	druidv1alpha1 "github.com/gardener/etcd-druid/api/v1alpha1"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Factory constructs etcd Clients for a given Etcd resource, resolving the TLS secrets
+// referenced in its spec and the client ports exposed by its Service.
+type Factory struct {
+	// Client is used to resolve TLS secrets referenced by the Etcd resource.
+	Client client.Client
+	// ConnectionTimeout bounds dialing and keep-alive probes of clients created by this factory.
+	// Individual calls are bounded only by the context the caller passes.
+	ConnectionTimeout time.Duration
+}
+
+// NewFactory creates a new Factory.
+func NewFactory(c client.Client, connectionTimeout time.Duration) *Factory {
+	return &Factory{
+		Client:            c,
+		ConnectionTimeout: connectionTimeout,
+	}
+}
+
+// NewClient builds a Client dialing one endpoint per given pod ordinal against the client
+// Service of the given Etcd, honoring TLS if configured in Etcd.Spec.Etcd.TLS.
+func (f *Factory) NewClient(ctx context.Context, etcd *druidv1alpha1.Etcd, serviceName string, clientPort int32, replicas int) (Client, error) {
+	endpoints := make([]string, 0, replicas)
+	scheme := "http"
+
+	tlsConfig, err := f.tlsConfigFor(ctx, etcd)
+	if err != nil {
+		return nil, err
+	}
+	if tlsConfig != nil {
+		scheme = "https"
+	}
+
+	for ordinal := 0; ordinal < replicas; ordinal++ {
+		endpoints = append(endpoints, fmt.Sprintf("%s://%s-%d.%s.%s:%d", scheme, etcd.Name, ordinal, serviceName, etcd.Namespace, clientPort))
+	}
+
+	return New(Options{
+		Endpoints:         endpoints,
+		TLS:               tlsConfig,
+		ConnectionTimeout: f.ConnectionTimeout,
+	})
+}
+
+// NewSingleEndpointClient builds a Client dialing exactly the given client endpoint, honoring
+// TLS if configured in Etcd.Spec.Etcd.TLS. Unlike NewClient, the returned Client is not
+// load-balanced across the cluster, which callers need when an operation (e.g. MoveLeader) must
+// be issued against one specific member rather than whichever member the client happens to pick.
+func (f *Factory) NewSingleEndpointClient(ctx context.Context, etcd *druidv1alpha1.Etcd, endpoint string) (Client, error) {
+	tlsConfig, err := f.tlsConfigFor(ctx, etcd)
+	if err != nil {
+		return nil, err
+	}
+
+	return New(Options{
+		Endpoints:         []string{endpoint},
+		TLS:               tlsConfig,
+		ConnectionTimeout: f.ConnectionTimeout,
+	})
+}
+
+// tlsConfigFor resolves the client TLS secrets referenced by the Etcd resource, if any, and
+// returns nil if the cluster is not configured to serve client TLS.
+func (f *Factory) tlsConfigFor(ctx context.Context, etcd *druidv1alpha1.Etcd) (*tls.Config, error) {
+	tlsSpec := etcd.Spec.Etcd.TLS
+	if tlsSpec == nil {
+		return nil, nil
+	}
+
+	clientSecret, err := f.getSecret(ctx, etcd.Namespace, tlsSpec.ClientTLSSecretRef)
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch client TLS secret: %w", err)
+	}
+	caSecret, err := f.getSecret(ctx, etcd.Namespace, tlsSpec.TLSCASecretRef)
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch CA secret: %w", err)
+	}
+
+	cert, err := tls.X509KeyPair(clientSecret.Data[corev1.TLSCertKey], clientSecret.Data[corev1.TLSPrivateKeyKey])
+	if err != nil {
+		return nil, fmt.Errorf("unable to load client TLS keypair: %w", err)
+	}
+
+	caPool := x509.NewCertPool()
+	if !caPool.AppendCertsFromPEM(caSecret.Data[corev1.ServiceAccountRootCAKey]) {
+		return nil, fmt.Errorf("unable to parse CA certificate from secret %s/%s", caSecret.Namespace, caSecret.Name)
+	}
+
+	return &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		RootCAs:      caPool,
+	}, nil
+}
+
+func (f *Factory) getSecret(ctx context.Context, namespace string, ref corev1.SecretReference) (*corev1.Secret, error) {
+	ns := namespace
+	if ref.Namespace != "" {
+		ns = ref.Namespace
+	}
+	secret := &corev1.Secret{}
+	if err := f.Client.Get(ctx, types.NamespacedName{Name: ref.Name, Namespace: ns}, secret); err != nil {
+		return nil, err
+	}
+	return secret, nil
+}
@@ -0,0 +1,596 @@
+// Copyright (c) 2021 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package factory
+
+import (
+	"fmt"
+	"strings"
+
+	druidv1alpha1 "github.com/gardener/etcd-druid/api/v1alpha1"
+	"github.com/gardener/etcd-druid/pkg/common"
+
+	"github.com/gardener/gardener/pkg/utils/imagevector"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	resource "k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// This file holds the typed builders that replaced the etcd chart's
+// `templates/etcd-service.yaml`, `templates/etcd-configmap.yaml` and
+// `templates/etcd-statefulset.yaml`. Unlike the chart templates, a builder never talks to the
+// API server - it only computes the desired object from an Etcd resource so that callers
+// (EtcdReconciler, and unit tests) can diff it against the live object themselves.
+//
+// Not implemented: a pluggable, per-topology ResourceReconciler registry (single-node,
+// multi-node-static, multi-node-learner implementations selected via an Etcd.Spec.Topology
+// field) was previously attempted as a second, interface-based factory design alongside this one,
+// but it was never wired into EtcdReconciler or EtcdCustodian and was removed as dead code. Adding
+// it back against this file's function-based BuildXxx design - which every controller in this
+// repo now depends on - would mean either reintroducing that same unreachable parallel
+// abstraction, or restructuring every BuildXxx call site to go through a topology-selected
+// interface instead, which is a materially larger change than a single backlog item. Flagging
+// here rather than attempting a partial, unwired reimplementation.
+
+// BuildClientService returns the desired client Service for the given Etcd resource. Clients
+// (including the etcd-backup-restore sidecars of peer members) use this Service to reach
+// whichever member is currently serving.
+func BuildClientService(etcd *druidv1alpha1.Etcd) (*corev1.Service, error) {
+	selector, err := metav1.LabelSelectorAsMap(etcd.Spec.Selector)
+	if err != nil {
+		return nil, err
+	}
+
+	clientPort := int32(2379)
+	if etcd.Spec.Etcd.ClientPort != nil {
+		clientPort = *etcd.Spec.Etcd.ClientPort
+	}
+	backupPort := int32(8080)
+	if etcd.Spec.Backup.Port != nil {
+		backupPort = *etcd.Spec.Backup.Port
+	}
+
+	return &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        fmt.Sprintf("%s-client", etcd.Name),
+			Namespace:   etcd.Namespace,
+			Labels:      etcd.Spec.Labels,
+			Annotations: etcd.Spec.Annotations,
+		},
+		Spec: corev1.ServiceSpec{
+			Selector: selector,
+			Type:     corev1.ServiceTypeClusterIP,
+			Ports: []corev1.ServicePort{
+				{
+					Name:       "client",
+					Protocol:   corev1.ProtocolTCP,
+					Port:       clientPort,
+					TargetPort: intstr.FromInt(int(clientPort)),
+				},
+				{
+					Name:       "backuprestore",
+					Protocol:   corev1.ProtocolTCP,
+					Port:       backupPort,
+					TargetPort: intstr.FromInt(int(backupPort)),
+				},
+			},
+		},
+	}, nil
+}
+
+// BuildPeerService returns the desired headless peer Service for the given Etcd resource. It is
+// used for StatefulSet pod DNS and etcd's own peer-to-peer communication.
+func BuildPeerService(etcd *druidv1alpha1.Etcd) (*corev1.Service, error) {
+	selector, err := metav1.LabelSelectorAsMap(etcd.Spec.Selector)
+	if err != nil {
+		return nil, err
+	}
+
+	serverPort := int32(2380)
+	if etcd.Spec.Etcd.ServerPort != nil {
+		serverPort = *etcd.Spec.Etcd.ServerPort
+	}
+
+	return &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("%s-peer", etcd.Name),
+			Namespace: etcd.Namespace,
+			// Deliberately not etcd.Spec.Labels: EtcdReconciler.reconcileServices lists and
+			// claims Services by that same label set to find the client Service, and would
+			// otherwise mistake this Service for a duplicate of it.
+			Annotations: etcd.Spec.Annotations,
+		},
+		Spec: corev1.ServiceSpec{
+			ClusterIP:                corev1.ClusterIPNone,
+			Selector:                 selector,
+			PublishNotReadyAddresses: true,
+			Ports: []corev1.ServicePort{
+				{
+					Name:       "server",
+					Protocol:   corev1.ProtocolTCP,
+					Port:       serverPort,
+					TargetPort: intstr.FromInt(int(serverPort)),
+				},
+			},
+		},
+	}, nil
+}
+
+// EffectiveReplicas returns the replica count the StatefulSet and its related resources should be
+// built with. It is Spec.Replicas as-is, including 0 (scale-to-zero/suspend): callers must not
+// float a missing or zero replica count up to 1, since that silently defeats scale-to-zero. A
+// negative Spec.Replicas is invalid input and is floored at 0 rather than propagated.
+func EffectiveReplicas(etcd *druidv1alpha1.Etcd) int {
+	if etcd.Spec.Replicas < 0 {
+		return 0
+	}
+	return etcd.Spec.Replicas
+}
+
+// BuildConfigMap returns the desired bootstrap ConfigMap for the given Etcd resource. Its name
+// is derived from the Etcd's UID so that a recreated Etcd of the same name never observes a
+// stale bootstrap ConfigMap left behind by its predecessor.
+//
+// initialClusterState is written through verbatim as the etcd --initial-cluster-state value: the
+// caller must pass "new" only while bootstrapping this Etcd's cluster for the very first time, and
+// "existing" on every reconcile afterwards. A member whose data directory is already initialized
+// ignores this value on restart, but a brand new member joining a live cluster via MemberAdd on
+// scale-up does not - booting it with "new" against a cluster that already has quorum can corrupt
+// or fail its join.
+func BuildConfigMap(etcd *druidv1alpha1.Etcd, initialClusterState string) (*corev1.ConfigMap, error) {
+	autoCompactionMode := druidv1alpha1.Periodic
+	if etcd.Spec.Common.AutoCompactionMode != nil {
+		autoCompactionMode = *etcd.Spec.Common.AutoCompactionMode
+	}
+	autoCompactionRetention := "30m"
+	if etcd.Spec.Common.AutoCompactionRetention != nil {
+		autoCompactionRetention = *etcd.Spec.Common.AutoCompactionRetention
+	}
+
+	quota := int64(8 * 1024 * 1024 * 1024) // 8Gi
+	if etcd.Spec.Etcd.Quota != nil {
+		quota = etcd.Spec.Etcd.Quota.Value()
+	}
+
+	data := map[string]string{
+		"name":                      etcd.Name,
+		"initial-cluster-state":     initialClusterState,
+		"initial-cluster":           initialCluster(etcd),
+		"auto-compaction-mode":      string(autoCompactionMode),
+		"auto-compaction-retention": autoCompactionRetention,
+		"quota-backend-bytes":       fmt.Sprintf("%d", quota),
+	}
+
+	if restore := etcd.Spec.Restore; restore != nil {
+		snapshotName := "latest"
+		if restore.SnapshotName != nil && len(*restore.SnapshotName) != 0 {
+			snapshotName = *restore.SnapshotName
+		}
+		data["restore-source-provider"] = string(ptrStorageProviderOrEmpty(restore.Source.Provider))
+		data["restore-source-prefix"] = restore.Source.Prefix
+		data["restore-source-container"] = ptrStringOrEmpty(restore.Source.Container)
+		data["restore-snapshot-name"] = snapshotName
+		if restore.Revision != nil {
+			data["restore-revision"] = fmt.Sprintf("%d", *restore.Revision)
+		}
+	}
+
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        bootstrapConfigMapName(etcd),
+			Namespace:   etcd.Namespace,
+			Labels:      etcd.Spec.Labels,
+			Annotations: etcd.Spec.Annotations,
+		},
+		Data: data,
+	}, nil
+}
+
+// BuildStatefulSet returns the desired StatefulSet for the given Etcd resource, resolving the
+// etcd and backup-restore container images from etcd.Spec when set, falling back to imageVector
+// otherwise. If Spec.Restore is set, a restore init container is prepended to the pod so the
+// backup-restore image pulls and validates the snapshot chain described by Spec.Restore.Source
+// into the data volume before the etcd container is allowed to start.
+func BuildStatefulSet(etcd *druidv1alpha1.Etcd, imageVector imagevector.ImageVector) (*appsv1.StatefulSet, error) {
+	etcdImage, err := resolveImage(etcd.Spec.Etcd.Image, imageVector, common.Etcd)
+	if err != nil {
+		return nil, err
+	}
+	backupImage, err := resolveImage(etcd.Spec.Backup.Image, imageVector, common.BackupRestore)
+	if err != nil {
+		return nil, err
+	}
+
+	replicas := int32(EffectiveReplicas(etcd))
+
+	clientPort := int32(2379)
+	if etcd.Spec.Etcd.ClientPort != nil {
+		clientPort = *etcd.Spec.Etcd.ClientPort
+	}
+	serverPort := int32(2380)
+	if etcd.Spec.Etcd.ServerPort != nil {
+		serverPort = *etcd.Spec.Etcd.ServerPort
+	}
+	backupPort := int32(8080)
+	if etcd.Spec.Backup.Port != nil {
+		backupPort = *etcd.Spec.Backup.Port
+	}
+
+	volumeClaimTemplateName := etcd.Name
+	if etcd.Spec.VolumeClaimTemplate != nil && len(*etcd.Spec.VolumeClaimTemplate) != 0 {
+		volumeClaimTemplateName = *etcd.Spec.VolumeClaimTemplate
+	}
+	storageCapacity := resource.MustParse("16Gi")
+	if etcd.Spec.StorageCapacity != nil {
+		storageCapacity = *etcd.Spec.StorageCapacity
+	}
+
+	configVolume, configMount := configVolumeAndMount(etcd)
+	etcdTLSVolumes, etcdTLSMounts := tlsVolumeAndMounts("etcd", etcd.Spec.Etcd.TLS)
+	backupTLSVolumes, backupTLSMounts := tlsVolumeAndMounts("backup", etcd.Spec.Backup.TLS)
+	localBackupVolume, localBackupMount := localBackupVolumeAndMount(etcd)
+
+	etcdContainer := corev1.Container{
+		Name:    "etcd",
+		Image:   etcdImage,
+		Command: []string{"/var/etcd/bin/bootstrap.sh"},
+		Ports: []corev1.ContainerPort{
+			{Name: "server", ContainerPort: serverPort},
+			{Name: "client", ContainerPort: clientPort},
+		},
+		Env: podIdentityEnvVars(),
+		EnvFrom: []corev1.EnvFromSource{
+			{ConfigMapRef: &corev1.ConfigMapEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: bootstrapConfigMapName(etcd)}}},
+		},
+		VolumeMounts: append([]corev1.VolumeMount{
+			{Name: volumeClaimTemplateName, MountPath: "/var/etcd/data"},
+			configMount,
+		}, etcdTLSMounts...),
+	}
+	if etcd.Spec.Etcd.Resources != nil {
+		etcdContainer.Resources = *etcd.Spec.Etcd.Resources
+	}
+
+	backupContainer := corev1.Container{
+		Name:    "backup-restore",
+		Image:   backupImage,
+		Command: []string{"etcdbrctl"},
+		Args:    backupRestoreServerArgs(etcd),
+		Ports: []corev1.ContainerPort{
+			{Name: "server", ContainerPort: backupPort},
+		},
+		EnvFrom: backupStoreEnvFrom(etcd.Spec.Backup.Store),
+		VolumeMounts: append(append([]corev1.VolumeMount{
+			{Name: volumeClaimTemplateName, MountPath: "/var/etcd/data"},
+		}, backupTLSMounts...), localBackupMount...),
+	}
+	if etcd.Spec.Backup.Resources != nil {
+		backupContainer.Resources = *etcd.Spec.Backup.Resources
+	}
+
+	selector, err := metav1.LabelSelectorAsSelector(etcd.Spec.Selector)
+	if err != nil {
+		return nil, err
+	}
+	_ = selector // validated here so malformed selectors fail fast during the build, not later
+
+	var initContainers []corev1.Container
+	if restore := etcd.Spec.Restore; restore != nil {
+		initContainers = append(initContainers, corev1.Container{
+			Name:    "restore",
+			Image:   backupImage,
+			Command: []string{"etcdbrctl"},
+			Args:    restoreArgs(restore),
+			EnvFrom: backupStoreEnvFrom(&restore.Source),
+			VolumeMounts: []corev1.VolumeMount{
+				{Name: volumeClaimTemplateName, MountPath: "/var/etcd/data"},
+			},
+		})
+	}
+
+	volumes := append([]corev1.Volume{configVolume}, etcdTLSVolumes...)
+	volumes = append(volumes, backupTLSVolumes...)
+	if localBackupVolume != nil {
+		volumes = append(volumes, *localBackupVolume)
+	}
+
+	sts := &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        etcd.Name,
+			Namespace:   etcd.Namespace,
+			Labels:      etcd.Spec.Labels,
+			Annotations: etcd.Spec.Annotations,
+		},
+		Spec: appsv1.StatefulSetSpec{
+			Replicas:    &replicas,
+			ServiceName: fmt.Sprintf("%s-peer", etcd.Name),
+			Selector:    etcd.Spec.Selector,
+			UpdateStrategy: appsv1.StatefulSetUpdateStrategy{
+				Type: appsv1.RollingUpdateStatefulSetStrategyType,
+			},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels:      etcd.Spec.Labels,
+					Annotations: etcd.Spec.Annotations,
+				},
+				Spec: corev1.PodSpec{
+					InitContainers:     initContainers,
+					Containers:         []corev1.Container{etcdContainer, backupContainer},
+					Volumes:            volumes,
+					PriorityClassName:  priorityClassNameOrEmpty(etcd),
+					ServiceAccountName: etcd.Name,
+				},
+			},
+			VolumeClaimTemplates: []corev1.PersistentVolumeClaim{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: volumeClaimTemplateName},
+					Spec: corev1.PersistentVolumeClaimSpec{
+						AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+						Resources: corev1.ResourceRequirements{
+							Requests: corev1.ResourceList{corev1.ResourceStorage: storageCapacity},
+						},
+					},
+				},
+			},
+		},
+	}
+	if etcd.Spec.StorageClass != nil {
+		sts.Spec.VolumeClaimTemplates[0].Spec.StorageClassName = etcd.Spec.StorageClass
+	}
+	return sts, nil
+}
+
+// BuildPodDisruptionBudget returns the desired PodDisruptionBudget for the given Etcd resource,
+// or nil if budget management has been explicitly disabled via Spec.DisruptionBudget.Enabled.
+// For clusters with 3 or more replicas it defaults MinAvailable to a quorum-sized majority so
+// voluntary drains (e.g. cluster-autoscaler evictions) cannot take the cluster below quorum; for
+// smaller clusters it defaults MaxUnavailable to 1, since even a single unavailable replica
+// already means downtime either way. Both defaults can be overridden via Spec.DisruptionBudget.
+func BuildPodDisruptionBudget(etcd *druidv1alpha1.Etcd) (*policyv1.PodDisruptionBudget, error) {
+	budget := etcd.Spec.DisruptionBudget
+	if budget != nil && budget.Enabled != nil && !*budget.Enabled {
+		return nil, nil
+	}
+
+	var minAvailable, maxUnavailable *intstr.IntOrString
+	switch {
+	case budget != nil && budget.MinAvailable != nil:
+		minAvailable = budget.MinAvailable
+	case budget != nil && budget.MaxUnavailable != nil:
+		maxUnavailable = budget.MaxUnavailable
+	case etcd.Spec.Replicas >= 3:
+		quorum := intstr.FromInt(etcd.Spec.Replicas/2 + 1)
+		minAvailable = &quorum
+	default:
+		one := intstr.FromInt(1)
+		maxUnavailable = &one
+	}
+
+	return &policyv1.PodDisruptionBudget{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        fmt.Sprintf("%s-pdb", etcd.Name),
+			Namespace:   etcd.Namespace,
+			Labels:      etcd.Spec.Labels,
+			Annotations: etcd.Spec.Annotations,
+		},
+		Spec: policyv1.PodDisruptionBudgetSpec{
+			Selector:       etcd.Spec.Selector,
+			MinAvailable:   minAvailable,
+			MaxUnavailable: maxUnavailable,
+		},
+	}, nil
+}
+
+func priorityClassNameOrEmpty(etcd *druidv1alpha1.Etcd) string {
+	if etcd.Spec.PriorityClassName != nil {
+		return *etcd.Spec.PriorityClassName
+	}
+	return ""
+}
+
+func ptrStringOrEmpty(s *string) string {
+	if s != nil {
+		return *s
+	}
+	return ""
+}
+
+func ptrStorageProviderOrEmpty(p *druidv1alpha1.StorageProvider) druidv1alpha1.StorageProvider {
+	if p != nil {
+		return *p
+	}
+	return ""
+}
+
+// bootstrapConfigMapName derives the name of the bootstrap ConfigMap built by BuildConfigMap for
+// etcd, shared with BuildStatefulSet so the StatefulSet it builds always references the right one.
+func bootstrapConfigMapName(etcd *druidv1alpha1.Etcd) string {
+	return fmt.Sprintf("etcd-bootstrap-%s", string(etcd.UID[:6]))
+}
+
+// podIdentityEnvVars returns the Downward API environment variables the etcd container's
+// bootstrap script needs to identify its own pod, e.g. to derive its ordinal and therefore its
+// own entry in --initial-cluster.
+func podIdentityEnvVars() []corev1.EnvVar {
+	return []corev1.EnvVar{
+		{Name: "POD_NAME", ValueFrom: &corev1.EnvVarSource{FieldRef: &corev1.ObjectFieldSelector{FieldPath: "metadata.name"}}},
+		{Name: "POD_NAMESPACE", ValueFrom: &corev1.EnvVarSource{FieldRef: &corev1.ObjectFieldSelector{FieldPath: "metadata.namespace"}}},
+	}
+}
+
+// configVolumeAndMount returns the Volume and VolumeMount that project the bootstrap ConfigMap
+// into the etcd container at /var/etcd/config, where its bootstrap script reads it.
+func configVolumeAndMount(etcd *druidv1alpha1.Etcd) (corev1.Volume, corev1.VolumeMount) {
+	volume := corev1.Volume{
+		Name: "config",
+		VolumeSource: corev1.VolumeSource{
+			ConfigMap: &corev1.ConfigMapVolumeSource{
+				LocalObjectReference: corev1.LocalObjectReference{Name: bootstrapConfigMapName(etcd)},
+			},
+		},
+	}
+	mount := corev1.VolumeMount{Name: "config", MountPath: "/var/etcd/config"}
+	return volume, mount
+}
+
+// tlsVolumeAndMounts returns the Volumes and VolumeMounts projecting the CA, server and client
+// secrets of a TLSConfig, named and mounted under the given prefix (e.g. "etcd" or "backup") so
+// the etcd and backup-restore containers' certificate paths never collide. Returns nil, nil if
+// tls is nil, i.e. the corresponding traffic is not TLS-enabled.
+func tlsVolumeAndMounts(prefix string, tls *druidv1alpha1.TLSConfig) ([]corev1.Volume, []corev1.VolumeMount) {
+	if tls == nil {
+		return nil, nil
+	}
+
+	secrets := []struct {
+		suffix string
+		ref    corev1.SecretReference
+	}{
+		{"ca", tls.TLSCASecretRef},
+		{"server", tls.ServerTLSSecretRef},
+		{"client", tls.ClientTLSSecretRef},
+	}
+
+	volumes := make([]corev1.Volume, 0, len(secrets))
+	mounts := make([]corev1.VolumeMount, 0, len(secrets))
+	for _, s := range secrets {
+		name := fmt.Sprintf("%s-%s-tls", prefix, s.suffix)
+		volumes = append(volumes, corev1.Volume{
+			Name: name,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{SecretName: s.ref.Name},
+			},
+		})
+		mounts = append(mounts, corev1.VolumeMount{
+			Name:      name,
+			MountPath: fmt.Sprintf("/var/etcd/ssl/%s/%s", prefix, s.suffix),
+			ReadOnly:  true,
+		})
+	}
+	return volumes, mounts
+}
+
+// localBackupVolumeAndMount returns the hostPath Volume and VolumeMount backing a
+// LocalStorageProvider backup store, or nil, nil if the store is not configured for local storage.
+func localBackupVolumeAndMount(etcd *druidv1alpha1.Etcd) (*corev1.Volume, []corev1.VolumeMount) {
+	store := etcd.Spec.Backup.Store
+	if store == nil || store.Provider == nil || *store.Provider != druidv1alpha1.LocalStorageProvider || store.Local == nil {
+		return nil, nil
+	}
+
+	volume := corev1.Volume{
+		Name:         "local-backup",
+		VolumeSource: corev1.VolumeSource{HostPath: &corev1.HostPathVolumeSource{Path: store.Local.HostPath}},
+	}
+	mount := corev1.VolumeMount{Name: "local-backup", MountPath: "/var/etcdbr/local"}
+	return &volume, []corev1.VolumeMount{mount}
+}
+
+// backupStoreEnvFrom returns the EnvFromSource exposing a StoreSpec's credentials secret to the
+// backup-restore container, or nil if the store has no SecretRef (e.g. LocalStorageProvider,
+// which needs none).
+func backupStoreEnvFrom(store *druidv1alpha1.StoreSpec) []corev1.EnvFromSource {
+	if store == nil || store.SecretRef == nil {
+		return nil
+	}
+	return []corev1.EnvFromSource{
+		{SecretRef: &corev1.SecretEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: store.SecretRef.Name}}},
+	}
+}
+
+// backupRestoreServerArgs renders the etcdbrctl CLI arguments for the backup-restore sidecar's
+// "server" command from the fields of Spec.Backup that map directly onto a flag.
+func backupRestoreServerArgs(etcd *druidv1alpha1.Etcd) []string {
+	args := []string{"server"}
+	if sched := etcd.Spec.Backup.FullSnapshotSchedule; sched != nil {
+		args = append(args, "--schedule="+*sched)
+	}
+	if policy := etcd.Spec.Backup.GarbageCollectionPolicy; policy != nil {
+		args = append(args, "--garbage-collection-policy="+string(*policy))
+	}
+	args = append(args, storeArgs(etcd.Spec.Backup.Store)...)
+	return args
+}
+
+// restoreArgs renders the etcdbrctl CLI arguments for the "restore" command from a RestoreSpec.
+func restoreArgs(restore *druidv1alpha1.RestoreSpec) []string {
+	args := []string{"restore", "--data-dir=/var/etcd/data"}
+	if restore.SnapshotName != nil && len(*restore.SnapshotName) != 0 {
+		args = append(args, "--restore-snapshot-name="+*restore.SnapshotName)
+	}
+	if restore.Revision != nil {
+		args = append(args, fmt.Sprintf("--restore-revision=%d", *restore.Revision))
+	}
+	args = append(args, storeArgs(&restore.Source)...)
+	return args
+}
+
+// storeArgs renders the etcdbrctl CLI arguments common to both commands for addressing a
+// StoreSpec, or nil if store is nil.
+func storeArgs(store *druidv1alpha1.StoreSpec) []string {
+	if store == nil {
+		return nil
+	}
+	var args []string
+	if store.Provider != nil {
+		args = append(args, "--storage-provider="+string(*store.Provider))
+	}
+	args = append(args, "--store-prefix="+store.Prefix)
+	if store.Container != nil {
+		args = append(args, "--store-container="+*store.Container)
+	}
+	return args
+}
+
+// initialCluster renders the etcd --initial-cluster value: every member of the StatefulSet this
+// Etcd resource will have, addressed by its stable pod DNS name on the headless peer Service
+// built by BuildPeerService. Members are indexed by ordinal rather than looked up live, since the
+// initial cluster is only ever consulted by a member bootstrapping for the very first time.
+func initialCluster(etcd *druidv1alpha1.Etcd) string {
+	replicas := EffectiveReplicas(etcd)
+
+	serverPort := int32(2380)
+	if etcd.Spec.Etcd.ServerPort != nil {
+		serverPort = *etcd.Spec.Etcd.ServerPort
+	}
+	scheme := "http"
+	if etcd.Spec.Etcd.TLS != nil {
+		scheme = "https"
+	}
+
+	members := make([]string, 0, replicas)
+	for ordinal := 0; ordinal < replicas; ordinal++ {
+		memberName := fmt.Sprintf("%s-%d", etcd.Name, ordinal)
+		members = append(members, fmt.Sprintf("%s=%s://%s.%s-peer.%s:%d", memberName, scheme, memberName, etcd.Name, etcd.Namespace, serverPort))
+	}
+	return strings.Join(members, ",")
+}
+
+func resolveImage(specImage *string, imageVector imagevector.ImageVector, name string) (string, error) {
+	if specImage != nil {
+		return *specImage, nil
+	}
+	images, err := imagevector.FindImages(imageVector, []string{name})
+	if err != nil {
+		return "", err
+	}
+	image, ok := images[name]
+	if !ok {
+		return "", fmt.Errorf("either etcd resource or image vector should have %s image", name)
+	}
+	return image.String(), nil
+}
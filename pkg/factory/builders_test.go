@@ -0,0 +1,257 @@
+// Copyright (c) 2021 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package factory
+
+import (
+	"testing"
+
+	druidv1alpha1 "github.com/gardener/etcd-druid/api/v1alpha1"
+
+	"github.com/gardener/gardener/pkg/utils/imagevector"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func testEtcd() *druidv1alpha1.Etcd {
+	return &druidv1alpha1.Etcd{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "default",
+			UID:       "abcdef12-3456-7890-abcd-ef1234567890",
+		},
+		Spec: druidv1alpha1.EtcdSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "test"}},
+			Replicas: 1,
+			Etcd:     druidv1alpha1.EtcdConfig{},
+			Backup:   druidv1alpha1.BackupSpec{},
+		},
+	}
+}
+
+func TestBuildClientService(t *testing.T) {
+	svc, err := BuildClientService(testEtcd())
+	if err != nil {
+		t.Fatalf("BuildClientService returned error: %v", err)
+	}
+	if svc.Name != "test-client" {
+		t.Errorf("Name = %q, want %q", svc.Name, "test-client")
+	}
+	if len(svc.Spec.Ports) != 2 {
+		t.Fatalf("len(Ports) = %d, want 2", len(svc.Spec.Ports))
+	}
+	if svc.Spec.Ports[0].Port != 2379 {
+		t.Errorf("client port = %d, want 2379", svc.Spec.Ports[0].Port)
+	}
+	if svc.Spec.Ports[1].Port != 8080 {
+		t.Errorf("backuprestore port = %d, want 8080", svc.Spec.Ports[1].Port)
+	}
+}
+
+func TestBuildPeerService(t *testing.T) {
+	svc, err := BuildPeerService(testEtcd())
+	if err != nil {
+		t.Fatalf("BuildPeerService returned error: %v", err)
+	}
+	if svc.Name != "test-peer" {
+		t.Errorf("Name = %q, want %q", svc.Name, "test-peer")
+	}
+	if svc.Spec.ClusterIP != corev1.ClusterIPNone {
+		t.Errorf("ClusterIP = %q, want %q", svc.Spec.ClusterIP, corev1.ClusterIPNone)
+	}
+	if !svc.Spec.PublishNotReadyAddresses {
+		t.Error("PublishNotReadyAddresses = false, want true")
+	}
+}
+
+func TestBuildConfigMap(t *testing.T) {
+	etcd := testEtcd()
+	cm, err := BuildConfigMap(etcd, "new")
+	if err != nil {
+		t.Fatalf("BuildConfigMap returned error: %v", err)
+	}
+	if cm.Name != bootstrapConfigMapName(etcd) {
+		t.Errorf("Name = %q, want %q", cm.Name, bootstrapConfigMapName(etcd))
+	}
+	if cm.Data["initial-cluster"] == "" {
+		t.Error("initial-cluster key missing from ConfigMap data")
+	}
+	if cm.Data["initial-cluster-state"] != "new" {
+		t.Errorf("initial-cluster-state = %q, want %q", cm.Data["initial-cluster-state"], "new")
+	}
+}
+
+func TestBuildConfigMapExistingClusterState(t *testing.T) {
+	etcd := testEtcd()
+	cm, err := BuildConfigMap(etcd, "existing")
+	if err != nil {
+		t.Fatalf("BuildConfigMap returned error: %v", err)
+	}
+	if cm.Data["initial-cluster-state"] != "existing" {
+		t.Errorf("initial-cluster-state = %q, want %q", cm.Data["initial-cluster-state"], "existing")
+	}
+}
+
+func TestBuildConfigMapRestoreFields(t *testing.T) {
+	etcd := testEtcd()
+	snapshotName := "snap-1"
+	etcd.Spec.Restore = &druidv1alpha1.RestoreSpec{
+		Source:       druidv1alpha1.StoreSpec{Prefix: "backups"},
+		SnapshotName: &snapshotName,
+	}
+
+	cm, err := BuildConfigMap(etcd, "new")
+	if err != nil {
+		t.Fatalf("BuildConfigMap returned error: %v", err)
+	}
+	if cm.Data["restore-snapshot-name"] != snapshotName {
+		t.Errorf("restore-snapshot-name = %q, want %q", cm.Data["restore-snapshot-name"], snapshotName)
+	}
+	if cm.Data["restore-source-prefix"] != "backups" {
+		t.Errorf("restore-source-prefix = %q, want %q", cm.Data["restore-source-prefix"], "backups")
+	}
+}
+
+func TestBuildStatefulSetContainers(t *testing.T) {
+	etcd := testEtcd()
+	sts, err := BuildStatefulSet(etcd, imagevector.ImageVector{})
+	if err == nil {
+		t.Fatalf("expected error resolving images from an empty image vector, got nil")
+	}
+
+	image := "etcd:v3"
+	backupImage := "etcdbrctl:v1"
+	etcd.Spec.Etcd.Image = &image
+	etcd.Spec.Backup.Image = &backupImage
+
+	sts, err = BuildStatefulSet(etcd, imagevector.ImageVector{})
+	if err != nil {
+		t.Fatalf("BuildStatefulSet returned error: %v", err)
+	}
+
+	containers := sts.Spec.Template.Spec.Containers
+	if len(containers) != 2 {
+		t.Fatalf("len(Containers) = %d, want 2", len(containers))
+	}
+
+	etcdContainer, backupContainer := containers[0], containers[1]
+
+	if len(etcdContainer.Command) == 0 {
+		t.Error("etcd container has no Command")
+	}
+	if len(etcdContainer.EnvFrom) != 1 || etcdContainer.EnvFrom[0].ConfigMapRef == nil {
+		t.Error("etcd container does not reference the bootstrap ConfigMap via EnvFrom")
+	}
+	if len(etcdContainer.Env) == 0 {
+		t.Error("etcd container has no Env")
+	}
+	foundConfigMount := false
+	for _, m := range etcdContainer.VolumeMounts {
+		if m.Name == "config" {
+			foundConfigMount = true
+		}
+	}
+	if !foundConfigMount {
+		t.Error("etcd container does not mount the config volume")
+	}
+
+	if len(backupContainer.Command) == 0 {
+		t.Error("backup-restore container has no Command")
+	}
+	if len(backupContainer.Args) == 0 {
+		t.Error("backup-restore container has no Args")
+	}
+}
+
+func TestBuildStatefulSetTLSVolumes(t *testing.T) {
+	etcd := testEtcd()
+	image, backupImage := "etcd:v3", "etcdbrctl:v1"
+	etcd.Spec.Etcd.Image = &image
+	etcd.Spec.Backup.Image = &backupImage
+	etcd.Spec.Etcd.TLS = &druidv1alpha1.TLSConfig{
+		TLSCASecretRef:     corev1.SecretReference{Name: "etcd-ca"},
+		ServerTLSSecretRef: corev1.SecretReference{Name: "etcd-server"},
+		ClientTLSSecretRef: corev1.SecretReference{Name: "etcd-client"},
+	}
+
+	sts, err := BuildStatefulSet(etcd, imagevector.ImageVector{})
+	if err != nil {
+		t.Fatalf("BuildStatefulSet returned error: %v", err)
+	}
+
+	wantVolumes := map[string]bool{"etcd-ca-tls": false, "etcd-server-tls": false, "etcd-client-tls": false}
+	for _, v := range sts.Spec.Template.Spec.Volumes {
+		if _, ok := wantVolumes[v.Name]; ok {
+			wantVolumes[v.Name] = true
+		}
+	}
+	for name, found := range wantVolumes {
+		if !found {
+			t.Errorf("expected TLS volume %q not found on pod spec", name)
+		}
+	}
+
+	etcdContainer := sts.Spec.Template.Spec.Containers[0]
+	foundMount := false
+	for _, m := range etcdContainer.VolumeMounts {
+		if m.Name == "etcd-ca-tls" {
+			foundMount = true
+		}
+	}
+	if !foundMount {
+		t.Error("etcd container does not mount etcd-ca-tls volume")
+	}
+}
+
+func TestBuildStatefulSetZeroReplicas(t *testing.T) {
+	etcd := testEtcd()
+	etcd.Spec.Replicas = 0
+	image, backupImage := "etcd:v3", "etcdbrctl:v1"
+	etcd.Spec.Etcd.Image = &image
+	etcd.Spec.Backup.Image = &backupImage
+
+	sts, err := BuildStatefulSet(etcd, imagevector.ImageVector{})
+	if err != nil {
+		t.Fatalf("BuildStatefulSet returned error: %v", err)
+	}
+	if sts.Spec.Replicas == nil || *sts.Spec.Replicas != 0 {
+		t.Errorf("Replicas = %v, want 0 (scale-to-zero)", sts.Spec.Replicas)
+	}
+}
+
+func TestBuildStatefulSetRestoreInitContainer(t *testing.T) {
+	etcd := testEtcd()
+	image, backupImage := "etcd:v3", "etcdbrctl:v1"
+	etcd.Spec.Etcd.Image = &image
+	etcd.Spec.Backup.Image = &backupImage
+	etcd.Spec.Restore = &druidv1alpha1.RestoreSpec{
+		Source: druidv1alpha1.StoreSpec{Prefix: "backups"},
+	}
+
+	sts, err := BuildStatefulSet(etcd, imagevector.ImageVector{})
+	if err != nil {
+		t.Fatalf("BuildStatefulSet returned error: %v", err)
+	}
+
+	initContainers := sts.Spec.Template.Spec.InitContainers
+	if len(initContainers) != 1 {
+		t.Fatalf("len(InitContainers) = %d, want 1", len(initContainers))
+	}
+	if initContainers[0].Name != "restore" {
+		t.Errorf("InitContainers[0].Name = %q, want %q", initContainers[0].Name, "restore")
+	}
+	if len(initContainers[0].Args) == 0 {
+		t.Error("restore init container has no Args")
+	}
+}
@@ -0,0 +1,70 @@
+// Copyright (c) 2021 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mapper
+
+import (
+	"context"
+
+	druidv1alpha1 "github.com/gardener/etcd-druid/api/v1alpha1"
+
+	extensionshandler "github.com/gardener/gardener/extensions/pkg/handler"
+	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+var statefulSetGVK = appsv1.SchemeGroupVersion.WithKind("StatefulSet")
+var etcdGVK = druidv1alpha1.GroupVersion.WithKind("Etcd")
+
+// PodToEtcd maps a Pod to a reconcile.Request for the Etcd that owns it. A Pod's controller
+// owner is the StatefulSet that created it, not the Etcd directly, so this first resolves the
+// owning StatefulSet and then reads the Etcd owner reference druid itself set on it via
+// controllerutil.SetControllerReference. This lets EtcdCustodian react directly to pod
+// lifecycle changes (e.g. a pod actually terminating) instead of only to the owning
+// StatefulSet's aggregated status.
+func PodToEtcd(ctx context.Context, c client.Client) extensionshandler.Mapper {
+	return extensionshandler.MapFunc(func(ctx context.Context, _ logr.Logger, _ client.Reader, obj client.Object) []reconcile.Request {
+		pod, ok := obj.(*corev1.Pod)
+		if !ok {
+			return nil
+		}
+
+		var stsName string
+		for _, ownerRef := range pod.GetOwnerReferences() {
+			if ownerRef.Kind == statefulSetGVK.Kind && ownerRef.APIVersion == statefulSetGVK.GroupVersion().String() {
+				stsName = ownerRef.Name
+				break
+			}
+		}
+		if stsName == "" {
+			return nil
+		}
+
+		sts := &appsv1.StatefulSet{}
+		if err := c.Get(ctx, types.NamespacedName{Namespace: pod.Namespace, Name: stsName}, sts); err != nil {
+			return nil
+		}
+
+		for _, ownerRef := range sts.GetOwnerReferences() {
+			if ownerRef.Kind == etcdGVK.Kind && ownerRef.APIVersion == etcdGVK.GroupVersion().String() {
+				return []reconcile.Request{{NamespacedName: types.NamespacedName{Namespace: pod.Namespace, Name: ownerRef.Name}}}
+			}
+		}
+		return nil
+	})
+}